@@ -1,23 +1,42 @@
 package main
 
 import (
+    "bufio"
     "context"
     "encoding/json"
     "flag"
     "fmt"
+    "net/http"
     "os"
-    "sync"
+    "path/filepath"
+    "strconv"
+    "strings"
     "time"
 
     "go.uber.org/zap"
     "github.com/joho/godotenv"
+
+    "github.com/turboOrange/llmhub/pkg/cache"
+    "github.com/turboOrange/llmhub/pkg/providers"
+    providergrpc "github.com/turboOrange/llmhub/pkg/providers/grpc"
+    "github.com/turboOrange/llmhub/pkg/router"
+    "github.com/turboOrange/llmhub/pkg/server"
+    "github.com/turboOrange/llmhub/pkg/tools"
 )
 
 // Provider interface for LLMs
 type Provider interface {
     Name() string
     Enabled() bool
-    Query(ctx context.Context, prompt string, extra map[string]string) (string, error)
+
+    // Query sends prompt (and, if non-empty, toolList) to the model. If
+    // the model wants to call a tool, Response.FinishReason is
+    // "tool_calls" and Response.ToolCalls is populated instead of Content.
+    Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error)
+
+    // QueryStream sends prompt and streams back partial answers. The
+    // final chunk on the channel carries FinishReason and Usage.
+    QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error)
 }
 
 // Example implementation for OpenAI (stub)
@@ -26,17 +45,129 @@ type OpenAIProvider struct {
     apiKey  string
 }
 
-func (o *OpenAIProvider) Name() string         { return "openai" }
-func (o *OpenAIProvider) Enabled() bool        { return o.enabled }
-func (o *OpenAIProvider) Query(ctx context.Context, prompt string, extra map[string]string) (string, error) {
-    // TODO: Call OpenAI API here using o.apiKey
-    return "OpenAI answer to: " + prompt, nil
+func (o *OpenAIProvider) Name() string  { return "openai" }
+func (o *OpenAIProvider) Enabled() bool { return o.enabled }
+func (o *OpenAIProvider) Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error) {
+    // requestTools is what the chat completions request's "tools" field
+    // would carry once the HTTP call below is implemented.
+    requestTools := openAIToolsPayload(toolList)
+
+    // TODO: Call OpenAI API here using o.apiKey, sending requestTools as
+    // the request's "tools" field and reading back
+    // choices[0].message.tool_calls.
+    _ = requestTools
+    return tools.Response{Content: "OpenAI answer to: " + prompt, FinishReason: "stop"}, nil
+}
+
+// Example implementation for Anthropic (stub)
+type AnthropicProvider struct {
+    enabled bool
+    apiKey  string
+}
+
+func (a *AnthropicProvider) Name() string  { return "anthropic" }
+func (a *AnthropicProvider) Enabled() bool { return a.enabled }
+func (a *AnthropicProvider) Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error) {
+    // requestTools is what the Messages API request's "tools" field
+    // would carry once the HTTP call below is implemented.
+    requestTools := anthropicToolsPayload(toolList)
+
+    // TODO: Call the Anthropic Messages API here using a.apiKey, sending
+    // requestTools as the request's "tools" field and reading back
+    // content[].type == "tool_use" blocks.
+    _ = requestTools
+    return tools.Response{Content: "Anthropic answer to: " + prompt, FinishReason: "stop"}, nil
+}
+
+func (a *AnthropicProvider) QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error) {
+    out := make(chan providers.Chunk)
+    go func() {
+        defer close(out)
+        // TODO: Call Anthropic's streaming API here using a.apiKey.
+        answer := "Anthropic answer to: " + prompt
+        out <- providers.Chunk{Text: answer}
+        out <- providers.Chunk{
+            FinishReason: "stop",
+            Usage:        providers.TokenUsage{Prompt: len(prompt), Completion: len(answer), Total: len(prompt) + len(answer)},
+        }
+    }()
+    return out, nil
+}
+
+// Example implementation for Gemini (stub)
+type GeminiProvider struct {
+    enabled bool
+    apiKey  string
+}
+
+func (g *GeminiProvider) Name() string  { return "gemini" }
+func (g *GeminiProvider) Enabled() bool { return g.enabled }
+func (g *GeminiProvider) Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error) {
+    // requestTools is what the generateContent request's "tools" field
+    // would carry once the HTTP call below is implemented.
+    requestTools := geminiToolsPayload(toolList)
+
+    // TODO: Call the Gemini generateContent API here using g.apiKey,
+    // sending requestTools as the request's "tools" field and reading
+    // back functionCall parts.
+    _ = requestTools
+    return tools.Response{Content: "Gemini answer to: " + prompt, FinishReason: "stop"}, nil
+}
+
+func (g *GeminiProvider) QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error) {
+    out := make(chan providers.Chunk)
+    go func() {
+        defer close(out)
+        // TODO: Call Gemini's streaming API here using g.apiKey.
+        answer := "Gemini answer to: " + prompt
+        out <- providers.Chunk{Text: answer}
+        out <- providers.Chunk{
+            FinishReason: "stop",
+            Usage:        providers.TokenUsage{Prompt: len(prompt), Completion: len(answer), Total: len(prompt) + len(answer)},
+        }
+    }()
+    return out, nil
+}
+
+// QueryStream simulates reading the "data: " SSE frames OpenAI's
+// streaming chat-completions endpoint returns, including the trailing
+// frame that carries "usage" once `stream_options.include_usage` is set.
+func (o *OpenAIProvider) QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error) {
+    out := make(chan providers.Chunk)
+    go func() {
+        defer close(out)
+        // TODO: Call OpenAI's streaming API here using o.apiKey and parse
+        // each "data: {...}" SSE frame, e.g.:
+        //   choices[0].delta.content -> Chunk.Text
+        //   choices[0].finish_reason -> Chunk.FinishReason
+        //   usage.{prompt,completion,total}_tokens -> Chunk.Usage (final frame)
+        answer := "OpenAI answer to: " + prompt
+        out <- providers.Chunk{Text: answer}
+        out <- providers.Chunk{
+            FinishReason: "stop",
+            Usage:        providers.TokenUsage{Prompt: len(prompt), Completion: len(answer), Total: len(prompt) + len(answer)},
+        }
+    }()
+    return out, nil
 }
 
 // Config: only enable/disable
 type Config struct {
     EnabledProviders map[string]bool
     Debug            bool
+
+    // Providers configures out-of-process provider plugins, keyed by
+    // provider name. A name with an entry here and EnabledProviders[name]
+    // true is dialed/started as a gRPC plugin instead of a built-in.
+    Providers map[string]providergrpc.PluginConfig `json:"providers"`
+
+    // Routing configures provider selection, retries, and fallback. An
+    // empty block keeps the original "query everyone" behavior.
+    Routing router.Config `json:"routing"`
+
+    // Cache configures memoizing Query answers. An empty/disabled block
+    // means every call reaches the provider.
+    Cache cache.Config `json:"cache"`
 }
 
 // ---------- Functions ----------
@@ -81,12 +212,27 @@ func setupLogger(debug bool) (*zap.Logger, error) {
     return loggerCfg.Build()
 }
 
-// Returns a list of enabled providers
-func getEnabledProviders(cfg *Config, apiKeys map[string]string) []Provider {
+// Returns a list of enabled providers, mixing built-ins with any
+// configured gRPC plugins uniformly.
+func getEnabledProviders(cfg *Config, apiKeys map[string]string, registry *providergrpc.Registry, logger *zap.Logger) []Provider {
     allProviders := []Provider{
         &OpenAIProvider{enabled: cfg.EnabledProviders["openai"], apiKey: apiKeys["openai"]},
-        // Add more providers here, e.g. AnthropicProvider, GeminiProvider
+        &AnthropicProvider{enabled: cfg.EnabledProviders["anthropic"], apiKey: apiKeys["anthropic"]},
+        &GeminiProvider{enabled: cfg.EnabledProviders["gemini"], apiKey: apiKeys["gemini"]},
     }
+
+    for name, pluginCfg := range cfg.Providers {
+        if !cfg.EnabledProviders[name] {
+            continue
+        }
+        client, err := registry.Start(name, pluginCfg, cfg.EnabledProviders[name])
+        if err != nil {
+            logger.Error("Failed to start provider plugin", zap.String("provider", name), zap.Error(err))
+            continue
+        }
+        allProviders = append(allProviders, client)
+    }
+
     enabled := []Provider{}
     for _, p := range allProviders {
         if p.Enabled() {
@@ -96,36 +242,33 @@ func getEnabledProviders(cfg *Config, apiKeys map[string]string) []Provider {
     return enabled
 }
 
-// Queries all enabled providers concurrently
-func queryProviders(ctx context.Context, providers []Provider, prompt string, logger *zap.Logger) (map[string]string, map[string]error) {
-    var wg sync.WaitGroup
-    mu := sync.Mutex{}
-    results := make(map[string]string)
-    errs := make(map[string]error)
-
-    for _, p := range providers {
-        wg.Add(1)
-        go func(prov Provider) {
-            defer wg.Done()
-            answer, err := prov.Query(ctx, prompt, nil)
-            mu.Lock()
-            defer mu.Unlock()
-            if err != nil {
-                logger.Error("Provider failed", zap.String("provider", prov.Name()), zap.Error(err))
-                errs[prov.Name()] = err
-            } else {
-                logger.Info("Provider answered", zap.String("provider", prov.Name()))
-                results[prov.Name()] = answer
-            }
-        }(p)
+// Queries the enabled providers via the router, printing partial output
+// as it streams in instead of blocking until every provider finishes.
+func queryProviders(ctx context.Context, rt *router.Router, strategy router.Strategy, provs []Provider, prompt string, logger *zap.Logger) (map[string]string, map[string]providers.TokenUsage, map[string]error) {
+    routerProvs := make([]router.Provider, len(provs))
+    for i, p := range provs {
+        routerProvs[i] = p
+    }
+
+    onChunk := func(provider string, chunk providers.Chunk) {
+        if chunk.Text != "" {
+            fmt.Printf("[%s] %s", provider, chunk.Text)
+        }
+        if chunk.FinishReason != "" {
+            logger.Info("Provider answered", zap.String("provider", provider), zap.String("finish_reason", chunk.FinishReason))
+        }
     }
-    wg.Wait()
-    return results, errs
+
+    results, usage, errs := rt.Route(ctx, prompt, routerProvs, strategy, onChunk)
+    for name, err := range errs {
+        logger.Error("Provider failed", zap.String("provider", name), zap.Error(err))
+    }
+    return results, usage, errs
 }
 
 // Finds the summarizer provider by name
-func findSummarizerProvider(providers []Provider, name string) Provider {
-    for _, p := range providers {
+func findSummarizerProvider(provs []Provider, name string) Provider {
+    for _, p := range provs {
         if p.Name() == name && p.Enabled() {
             return p
         }
@@ -133,25 +276,161 @@ func findSummarizerProvider(providers []Provider, name string) Provider {
     return nil
 }
 
-// Summarizes all answers using the chosen provider
-func summarizeAnswers(ctx context.Context, provider Provider, answers map[string]string, logger *zap.Logger) (string, error) {
+// maxToolIterations bounds the agent loop in summarizeAnswers so a
+// model that keeps requesting tool calls can't hang the CLI forever.
+const maxToolIterations = 5
+
+// Summarizes all answers using the chosen provider, acting as a real
+// agent: if the summarizer asks for tool calls, they're executed via
+// registry and the results are fed back until it returns a final
+// answer. The prompt includes each provider's cumulative token usage so
+// the verdict doubles as a cost comparison.
+func summarizeAnswers(ctx context.Context, provider Provider, answers map[string]string, usage map[string]providers.TokenUsage, toolList []tools.Tool, registry *tools.Registry, logger *zap.Logger) (string, error) {
     prompt := "Summarize and provide a verdict for these answers:\n"
     for name, answer := range answers {
         prompt += fmt.Sprintf("[%s]: %s\n", name, answer)
+        if u, ok := usage[name]; ok {
+            prompt += fmt.Sprintf("[%s tokens]: prompt=%d completion=%d total=%d\n", name, u.Prompt, u.Completion, u.Total)
+        }
     }
     logger.Info("Passing answers to summarizer", zap.String("provider", provider.Name()))
-    return provider.Query(ctx, prompt, nil)
+
+    for i := 0; i < maxToolIterations; i++ {
+        resp, err := provider.Query(ctx, prompt, nil, toolList)
+        if err != nil {
+            return "", err
+        }
+        if resp.FinishReason != "tool_calls" || len(resp.ToolCalls) == 0 {
+            return resp.Content, nil
+        }
+
+        for _, call := range resp.ToolCalls {
+            msg, err := registry.Execute(ctx, call)
+            if err != nil {
+                logger.Warn("Tool call failed", zap.String("tool", call.Name), zap.Error(err))
+                msg = tools.ToolMessage{ToolCallID: call.ID, Content: fmt.Sprintf("error: %v", err)}
+            } else {
+                logger.Info("Executed tool call", zap.String("tool", call.Name))
+            }
+            prompt += fmt.Sprintf("[tool %s result]: %s\n", call.Name, msg.Content)
+        }
+    }
+    return "", fmt.Errorf("summarizer exceeded %d tool-call iterations", maxToolIterations)
+}
+
+// perProviderReport is one entry of jsonReport.PerProvider.
+type perProviderReport struct {
+    Name      string  `json:"name"`
+    Answer    string  `json:"answer"`
+    LatencyMs float64 `json:"latency_ms"`
+    Tokens    int     `json:"tokens"`
+    Error     string  `json:"error,omitempty"`
+}
+
+// jsonReport is the --output json document emitted instead of the plain
+// "----- Final Verdict -----" text, so results can be diffed or
+// benchmarked across runs.
+type jsonReport struct {
+    Prompt         string              `json:"prompt"`
+    PerProvider    []perProviderReport `json:"per_provider"`
+    Summary        string              `json:"summary"`
+    AgreementScore float64             `json:"agreement_score"`
+}
+
+// buildReport assembles a jsonReport from a completed query round.
+func buildReport(prompt string, results map[string]string, usage map[string]providers.TokenUsage, errs map[string]error, latencies map[string]float64, summary string, agreement float64) jsonReport {
+    names := make(map[string]bool, len(results)+len(errs))
+    for name := range results {
+        names[name] = true
+    }
+    for name := range errs {
+        names[name] = true
+    }
+
+    report := jsonReport{Prompt: prompt, Summary: summary, AgreementScore: agreement}
+    for name := range names {
+        entry := perProviderReport{Name: name, Answer: results[name], LatencyMs: latencies[name], Tokens: usage[name].Total}
+        if err, ok := errs[name]; ok {
+            entry.Error = err.Error()
+        }
+        report.PerProvider = append(report.PerProvider, entry)
+    }
+    return report
+}
+
+// persistReport writes report as indented JSON to a timestamped file in
+// dir, so repeated runs accumulate a history for benchmarking provider
+// quality over time.
+func persistReport(dir string, report jsonReport, logger *zap.Logger) {
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        logger.Warn("Failed to create report dir", zap.String("dir", dir), zap.Error(err))
+        return
+    }
+    path := filepath.Join(dir, fmt.Sprintf("report-%d.json", time.Now().UnixNano()))
+    data, err := json.MarshalIndent(report, "", "  ")
+    if err != nil {
+        logger.Warn("Failed to marshal report", zap.Error(err))
+        return
+    }
+    if err := os.WriteFile(path, data, 0644); err != nil {
+        logger.Warn("Failed to write report", zap.String("path", path), zap.Error(err))
+        return
+    }
+    logger.Info("Report persisted", zap.String("path", path))
+}
+
+// computeAgreementScore asks the summarizer provider to rate, on a 0..1
+// scale, how much the providers' answers agree with each other. The
+// summarizer is reused rather than adding a separate Embedder provider
+// for every backend, since it already has to read every answer to write
+// the verdict.
+func computeAgreementScore(ctx context.Context, provider Provider, answers map[string]string, logger *zap.Logger) float64 {
+    if len(answers) < 2 {
+        return 1
+    }
+
+    prompt := "Rate how much these answers agree with each other on a scale from 0 (completely different) to 1 (semantically identical). Respond with only the number, nothing else.\n"
+    for name, answer := range answers {
+        prompt += fmt.Sprintf("[%s]: %s\n", name, answer)
+    }
+
+    resp, err := provider.Query(ctx, prompt, nil, nil)
+    if err != nil {
+        logger.Warn("Agreement score query failed", zap.Error(err))
+        return 0
+    }
+    score, err := strconv.ParseFloat(strings.TrimSpace(resp.Content), 64)
+    if err != nil {
+        logger.Warn("Could not parse agreement score", zap.String("response", resp.Content), zap.Error(err))
+        return 0
+    }
+    return score
 }
 
 // ---------- Main ----------
 
 func main() {
+    if len(os.Args) > 1 && os.Args[1] == "serve" {
+        runServe(os.Args[2:])
+        return
+    }
+    runQuery(os.Args[1:])
+}
+
+// runQuery is the original single-shot CLI: query every enabled
+// provider for one prompt and print a summarized verdict.
+func runQuery(args []string) {
     prompt := flag.String("prompt", "", "Prompt to send to LLMs")
     summarizer := flag.String("summarizer", "openai", "LLM to use for summarizing")
     configPath := flag.String("config", "config.json", "Path to config file")
     envPath := flag.String("env", ".env", "Path to .env file for API keys")
     debug := flag.Bool("debug", false, "Enable debug logging")
-    flag.Parse()
+    strategy := flag.String("strategy", "fanout", "Query strategy: fanout (query every provider) or failover (try providers in order)")
+    noCache := flag.Bool("no-cache", false, "Bypass the response cache for this run")
+    cacheWarm := flag.String("cache-warm", "", "Path to a newline-delimited prompts file to pre-populate the cache from, then exit")
+    output := flag.String("output", "text", "Output format: text or json")
+    reportDir := flag.String("report-dir", "", "Directory to persist --output json reports to, for benchmarking over time")
+    flag.CommandLine.Parse(args)
 
     logger, err := setupLogger(*debug)
     if err != nil {
@@ -160,42 +439,34 @@ func main() {
     }
     defer logger.Sync()
 
-    if *prompt == "" {
+    if *prompt == "" && *cacheWarm == "" {
         logger.Fatal("Prompt is required")
         os.Exit(1)
     }
 
-    cfg, err := loadConfig(*configPath)
+    cfg, registry, enabledProviders, err := bootstrap(*configPath, *envPath, *debug, logger)
     if err != nil {
-        logger.Fatal("Failed to load config", zap.Error(err))
+        logger.Fatal("Startup failed", zap.Error(err))
         os.Exit(1)
     }
-    cfg.Debug = *debug
-
-    // Load env file
-    if err := godotenv.Load(*envPath); err != nil {
-        logger.Fatal("Failed to load .env file", zap.Error(err))
-        os.Exit(1)
-    }
-
-    // Get API keys from env
-    providerNames := make([]string, 0, len(cfg.EnabledProviders))
-    for name := range cfg.EnabledProviders {
-        providerNames = append(providerNames, name)
-    }
-    apiKeys := loadAPIKeys(providerNames)
+    defer registry.Shutdown()
 
     ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
     defer cancel()
 
-    enabledProviders := getEnabledProviders(cfg, apiKeys)
-    if len(enabledProviders) == 0 {
-        logger.Fatal("No enabled LLM providers")
-        os.Exit(1)
+    enabledProviders, cacheStats := setupCache(enabledProviders, cfg.Cache, *noCache, logger)
+    defer logCacheStats(cacheStats, logger)
+
+    if *cacheWarm != "" {
+        warmCache(ctx, enabledProviders, *cacheWarm, logger)
+        return
     }
 
-    logger.Info("Querying providers", zap.Int("count", len(enabledProviders)))
-    results, errs := queryProviders(ctx, enabledProviders, *prompt, logger)
+    rt := router.New(cfg.Routing, logger)
+
+    logger.Info("Querying providers", zap.Int("count", len(enabledProviders)), zap.String("strategy", *strategy))
+    results, usage, errs := queryProviders(ctx, rt, router.Strategy(*strategy), enabledProviders, *prompt, logger)
+    fmt.Println()
     if len(results) == 0 {
         logger.Fatal("No providers returned an answer")
         os.Exit(1)
@@ -207,12 +478,181 @@ func main() {
         os.Exit(1)
     }
 
-    summary, err := summarizeAnswers(ctx, summarizerProvider, results, logger)
+    toolRegistry, toolList := defaultTools()
+
+    summary, err := summarizeAnswers(ctx, summarizerProvider, results, usage, toolList, toolRegistry, logger)
     if err != nil {
         logger.Fatal("Summarizer failed", zap.Error(err))
         os.Exit(1)
     }
 
+    if *output == "json" {
+        agreement := computeAgreementScore(ctx, summarizerProvider, results, logger)
+        report := buildReport(*prompt, results, usage, errs, rt.Latencies(), summary, agreement)
+        data, err := json.MarshalIndent(report, "", "  ")
+        if err != nil {
+            logger.Fatal("Failed to marshal report", zap.Error(err))
+            os.Exit(1)
+        }
+        fmt.Println(string(data))
+        if *reportDir != "" {
+            persistReport(*reportDir, report, logger)
+        }
+        return
+    }
+
     fmt.Println("----- Final Verdict -----")
     fmt.Println(summary)
 }
+
+// runServe starts the OpenAI-compatible HTTP API described in
+// pkg/server, backed by the same providers and router as runQuery.
+func runServe(args []string) {
+    fs := flag.NewFlagSet("serve", flag.ExitOnError)
+    addr := fs.String("addr", ":8080", "Address to listen on")
+    configPath := fs.String("config", "config.json", "Path to config file")
+    envPath := fs.String("env", ".env", "Path to .env file for API keys")
+    debug := fs.Bool("debug", false, "Enable debug logging")
+    fs.Parse(args)
+
+    logger, err := setupLogger(*debug)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Logger setup failed: %v\n", err)
+        os.Exit(1)
+    }
+    defer logger.Sync()
+
+    cfg, registry, enabledProviders, err := bootstrap(*configPath, *envPath, *debug, logger)
+    if err != nil {
+        logger.Fatal("Startup failed", zap.Error(err))
+        os.Exit(1)
+    }
+    defer registry.Shutdown()
+
+    enabledProviders, cacheStats := setupCache(enabledProviders, cfg.Cache, false, logger)
+    defer logCacheStats(cacheStats, logger)
+
+    rt := router.New(cfg.Routing, logger)
+    toolRegistry, toolList := defaultTools()
+
+    routerProvs := make([]router.Provider, len(enabledProviders))
+    for i, p := range enabledProviders {
+        routerProvs[i] = p
+    }
+
+    srv := server.New(routerProvs, rt, toolRegistry, toolList, logger)
+    logger.Info("Serving OpenAI-compatible API", zap.String("addr", *addr))
+    if err := http.ListenAndServe(*addr, srv.Handler()); err != nil {
+        logger.Fatal("Server failed", zap.Error(err))
+        os.Exit(1)
+    }
+}
+
+// bootstrap loads config.json and .env, then builds the enabled
+// provider set (built-ins plus any gRPC plugins). Shared by runQuery
+// and runServe so both commands see identical provider wiring.
+func bootstrap(configPath, envPath string, debug bool, logger *zap.Logger) (*Config, *providergrpc.Registry, []Provider, error) {
+    cfg, err := loadConfig(configPath)
+    if err != nil {
+        return nil, nil, nil, fmt.Errorf("load config: %w", err)
+    }
+    cfg.Debug = debug
+
+    if err := godotenv.Load(envPath); err != nil {
+        return nil, nil, nil, fmt.Errorf("load .env file: %w", err)
+    }
+
+    providerNames := make([]string, 0, len(cfg.EnabledProviders))
+    for name := range cfg.EnabledProviders {
+        providerNames = append(providerNames, name)
+    }
+    apiKeys := loadAPIKeys(providerNames)
+
+    registry := providergrpc.NewRegistry(logger)
+    enabledProviders := getEnabledProviders(cfg, apiKeys, registry, logger)
+    if len(enabledProviders) == 0 {
+        registry.Shutdown()
+        return nil, nil, nil, fmt.Errorf("no enabled LLM providers")
+    }
+    return cfg, registry, enabledProviders, nil
+}
+
+// setupCache wraps every provider with a cache.Provider when caching is
+// enabled in cfg and not bypassed by disabled (the --no-cache flag).
+// Returns the (possibly wrapped) providers and the Stats used to log a
+// hit/miss ratio, or nil Stats if caching isn't active.
+func setupCache(provs []Provider, cfg cache.Config, disabled bool, logger *zap.Logger) ([]Provider, *cache.Stats) {
+    if disabled || !cfg.Enabled {
+        return provs, nil
+    }
+    c, err := cache.New(cfg)
+    if err != nil {
+        logger.Warn("Failed to initialize cache, continuing without it", zap.Error(err))
+        return provs, nil
+    }
+
+    stats := &cache.Stats{}
+    wrapped := make([]Provider, len(provs))
+    for i, p := range provs {
+        wrapped[i] = cache.Wrap(p, c, cfg.TTLFor(p.Name()), stats, logger)
+    }
+    return wrapped, stats
+}
+
+// logCacheStats reports the aggregate hit ratio across every wrapped
+// provider, if caching was active.
+func logCacheStats(stats *cache.Stats, logger *zap.Logger) {
+    if stats == nil {
+        return
+    }
+    hits, misses := stats.Snapshot()
+    logger.Info("Cache stats", zap.Int64("hits", hits), zap.Int64("misses", misses), zap.Float64("hit_ratio", stats.Ratio()))
+}
+
+// warmCache reads one prompt per line from path and queries every
+// provider for each, populating the cache without printing a verdict.
+func warmCache(ctx context.Context, provs []Provider, path string, logger *zap.Logger) {
+    f, err := os.Open(path)
+    if err != nil {
+        logger.Fatal("Failed to open cache-warm prompts file", zap.Error(err))
+        os.Exit(1)
+    }
+    defer f.Close()
+
+    count := 0
+    scanner := bufio.NewScanner(f)
+    for scanner.Scan() {
+        prompt := strings.TrimSpace(scanner.Text())
+        if prompt == "" {
+            continue
+        }
+        for _, p := range provs {
+            if !p.Enabled() {
+                continue
+            }
+            if _, err := p.Query(ctx, prompt, nil, nil); err != nil {
+                logger.Warn("Cache warm query failed", zap.String("provider", p.Name()), zap.Error(err))
+            }
+        }
+        count++
+    }
+    logger.Info("Cache warm complete", zap.Int("prompts", count))
+}
+
+// defaultTools returns the tool registry and schema the summarizer's
+// agent loop is offered. Today that's just current_time; register more
+// via toolRegistry.Register as the CLI grows real tools.
+func defaultTools() (*tools.Registry, []tools.Tool) {
+    toolRegistry := tools.NewRegistry()
+    toolRegistry.Register("current_time", func(ctx context.Context, arguments string) (string, error) {
+        return time.Now().UTC().Format(time.RFC3339), nil
+    })
+    toolList := []tools.Tool{
+        {
+            Name:        "current_time",
+            Description: "Returns the current UTC time, for when the summarizer needs to reason about freshness.",
+            Parameters:  map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+        },
+    }
+    return toolRegistry, toolList
+}
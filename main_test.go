@@ -0,0 +1,113 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    "go.uber.org/zap"
+
+    "github.com/turboOrange/llmhub/pkg/providers"
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+// fakeToolProvider returns a tool_calls response on its first Query call
+// and a final answer on the next, so tests can drive summarizeAnswers'
+// agent loop without a live API key. When unparseable is set, the final
+// answer is non-numeric text instead, for computeAgreementScore tests.
+type fakeToolProvider struct {
+    calls       int
+    unparseable bool
+}
+
+func (f *fakeToolProvider) Name() string  { return "fake" }
+func (f *fakeToolProvider) Enabled() bool { return true }
+
+func (f *fakeToolProvider) Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error) {
+    f.calls++
+    if f.unparseable {
+        return tools.Response{Content: "not a number", FinishReason: "stop"}, nil
+    }
+    if f.calls == 1 {
+        return tools.Response{
+            FinishReason: "tool_calls",
+            ToolCalls:    []tools.ToolCall{{ID: "1", Name: "current_time", Arguments: "{}"}},
+        }, nil
+    }
+    return tools.Response{Content: "done", FinishReason: "stop"}, nil
+}
+
+func (f *fakeToolProvider) QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error) {
+    out := make(chan providers.Chunk)
+    close(out)
+    return out, nil
+}
+
+func TestSummarizeAnswersExecutesToolCalls(t *testing.T) {
+    registry := tools.NewRegistry()
+    called := false
+    registry.Register("current_time", func(ctx context.Context, arguments string) (string, error) {
+        called = true
+        return "2026-07-27T00:00:00Z", nil
+    })
+
+    provider := &fakeToolProvider{}
+    summary, err := summarizeAnswers(context.Background(), provider, map[string]string{"openai": "hi"}, nil, nil, registry, zap.NewNop())
+    if err != nil {
+        t.Fatalf("summarizeAnswers returned error: %v", err)
+    }
+    if !called {
+        t.Fatal("expected the tool call to be executed")
+    }
+    if summary != "done" {
+        t.Fatalf("summary = %q, want %q", summary, "done")
+    }
+    if provider.calls != 2 {
+        t.Fatalf("provider.calls = %d, want 2 (one tool_calls round, one final answer)", provider.calls)
+    }
+}
+
+func TestBuildReportAssemblesPerProviderEntries(t *testing.T) {
+    results := map[string]string{"openai": "yes", "anthropic": "no"}
+    usage := map[string]providers.TokenUsage{"openai": {Total: 10}}
+    errs := map[string]error{"gemini": errors.New("timeout")}
+    latencies := map[string]float64{"openai": 120, "gemini": 5000}
+
+    report := buildReport("prompt", results, usage, errs, latencies, "summary", 0.5)
+
+    if report.Prompt != "prompt" || report.Summary != "summary" || report.AgreementScore != 0.5 {
+        t.Fatalf("report = %+v, want prompt/summary/agreement carried through unchanged", report)
+    }
+    if len(report.PerProvider) != 3 {
+        t.Fatalf("len(PerProvider) = %d, want 3 (openai, anthropic, gemini)", len(report.PerProvider))
+    }
+
+    byName := make(map[string]perProviderReport, len(report.PerProvider))
+    for _, entry := range report.PerProvider {
+        byName[entry.Name] = entry
+    }
+
+    if got := byName["openai"]; got.Answer != "yes" || got.Tokens != 10 || got.LatencyMs != 120 || got.Error != "" {
+        t.Fatalf("openai entry = %+v, want answer/tokens/latency with no error", got)
+    }
+    if got := byName["gemini"]; got.Answer != "" || got.Error != "timeout" {
+        t.Fatalf("gemini entry = %+v, want empty answer and the provider's error", got)
+    }
+}
+
+func TestComputeAgreementScoreSingleAnswerShortCircuits(t *testing.T) {
+    score := computeAgreementScore(context.Background(), nil, map[string]string{"openai": "hi"}, zap.NewNop())
+    if score != 1 {
+        t.Fatalf("score = %v, want 1 for fewer than two answers", score)
+    }
+}
+
+func TestComputeAgreementScoreParseFailureReturnsZero(t *testing.T) {
+    provider := &fakeToolProvider{unparseable: true}
+    answers := map[string]string{"openai": "a", "anthropic": "b"}
+
+    score := computeAgreementScore(context.Background(), provider, answers, zap.NewNop())
+    if score != 0 {
+        t.Fatalf("score = %v, want 0 when the summarizer's response isn't a number", score)
+    }
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    bolt "go.etcd.io/bbolt"
+)
+
+var boltBucket = []byte("llmhub_cache")
+
+type boltEntry struct {
+    Value     string    `json:"value"`
+    ExpiresAt time.Time `json:"expires_at"`
+}
+
+// BoltCache persists entries to a local BoltDB file, surviving process
+// restarts (unlike MemoryCache).
+type BoltCache struct {
+    db *bolt.DB
+}
+
+// NewBoltCache opens (creating if needed) the BoltDB file at path.
+func NewBoltCache(path string) (*BoltCache, error) {
+    if path == "" {
+        return nil, fmt.Errorf("cache: bolt_path is required for the bolt backend")
+    }
+    db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+    if err != nil {
+        return nil, fmt.Errorf("open bolt cache %q: %w", path, err)
+    }
+    if err := db.Update(func(tx *bolt.Tx) error {
+        _, err := tx.CreateBucketIfNotExists(boltBucket)
+        return err
+    }); err != nil {
+        db.Close()
+        return nil, fmt.Errorf("init bolt cache bucket: %w", err)
+    }
+    return &BoltCache{db: db}, nil
+}
+
+func (c *BoltCache) Get(ctx context.Context, key Key) (string, bool, error) {
+    var entry boltEntry
+    found := false
+    err := c.db.View(func(tx *bolt.Tx) error {
+        raw := tx.Bucket(boltBucket).Get([]byte(key))
+        if raw == nil {
+            return nil
+        }
+        if err := json.Unmarshal(raw, &entry); err != nil {
+            return err
+        }
+        found = true
+        return nil
+    })
+    if err != nil {
+        return "", false, err
+    }
+    if !found {
+        return "", false, nil
+    }
+    if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+        _ = c.Delete(ctx, key)
+        return "", false, nil
+    }
+    return entry.Value, true, nil
+}
+
+func (c *BoltCache) Set(ctx context.Context, key Key, value string, ttl time.Duration) error {
+    var expiresAt time.Time
+    if ttl > 0 {
+        expiresAt = time.Now().Add(ttl)
+    }
+    raw, err := json.Marshal(boltEntry{Value: value, ExpiresAt: expiresAt})
+    if err != nil {
+        return err
+    }
+    return c.db.Update(func(tx *bolt.Tx) error {
+        return tx.Bucket(boltBucket).Put([]byte(key), raw)
+    })
+}
+
+func (c *BoltCache) Delete(ctx context.Context, key Key) error {
+    return c.db.Update(func(tx *bolt.Tx) error {
+        return tx.Bucket(boltBucket).Delete([]byte(key))
+    })
+}
+
+// Close releases the underlying BoltDB file handle.
+func (c *BoltCache) Close() error {
+    return c.db.Close()
+}
@@ -0,0 +1,89 @@
+// Package cache memoizes Provider.Query answers by a content-addressed
+// key (provider|model|prompt|params), so re-asking the same prompt -
+// including when the summarizer re-runs on identical answers - doesn't
+// cost another round trip.
+package cache
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+)
+
+// Key is a content-addressed cache key, hex-encoded SHA-256.
+type Key string
+
+// NewKey hashes provider|model|prompt|params into a Key. params is
+// sorted by key first so the hash is stable regardless of map
+// iteration order.
+func NewKey(provider, model, prompt string, params map[string]string) Key {
+    names := make([]string, 0, len(params))
+    for k := range params {
+        names = append(names, k)
+    }
+    sort.Strings(names)
+
+    var sb strings.Builder
+    sb.WriteString(provider)
+    sb.WriteByte('|')
+    sb.WriteString(model)
+    sb.WriteByte('|')
+    sb.WriteString(prompt)
+    sb.WriteByte('|')
+    for _, k := range names {
+        fmt.Fprintf(&sb, "%s=%s&", k, params[k])
+    }
+
+    sum := sha256.Sum256([]byte(sb.String()))
+    return Key(hex.EncodeToString(sum[:]))
+}
+
+// Cache stores Query answers keyed by Key. Implementations must be
+// safe for concurrent use.
+type Cache interface {
+    Get(ctx context.Context, key Key) (value string, found bool, err error)
+    Set(ctx context.Context, key Key, value string, ttl time.Duration) error
+    Delete(ctx context.Context, key Key) error
+}
+
+// Config is the "cache:" block of config.json.
+type Config struct {
+    Enabled bool `json:"enabled"`
+    // Backend selects the implementation: "memory" (default), "bolt",
+    // or "redis".
+    Backend string `json:"backend"`
+
+    MaxSize           int            `json:"max_size"`            // memory backend: max entries, 0 = unbounded
+    TTLSeconds        int            `json:"ttl_seconds"`         // default entry TTL, 0 = never expires
+    ProviderTTLSeconds map[string]int `json:"provider_ttl_seconds"` // per-provider override
+
+    BoltPath  string `json:"bolt_path"`  // bolt backend: database file path
+    RedisAddr string `json:"redis_addr"` // redis backend: host:port
+}
+
+// New builds the Cache described by cfg.
+func New(cfg Config) (Cache, error) {
+    switch cfg.Backend {
+    case "", "memory":
+        return NewMemoryCache(cfg.MaxSize), nil
+    case "bolt":
+        return NewBoltCache(cfg.BoltPath)
+    case "redis":
+        return NewRedisCache(cfg.RedisAddr), nil
+    default:
+        return nil, fmt.Errorf("unknown cache backend %q", cfg.Backend)
+    }
+}
+
+// TTLFor returns the configured TTL for a provider, falling back to the
+// default TTLSeconds when no per-provider override is set.
+func (cfg Config) TTLFor(provider string) time.Duration {
+    if s, ok := cfg.ProviderTTLSeconds[provider]; ok {
+        return time.Duration(s) * time.Second
+    }
+    return time.Duration(cfg.TTLSeconds) * time.Second
+}
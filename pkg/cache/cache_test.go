@@ -0,0 +1,76 @@
+package cache
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+func TestNewKeyStableRegardlessOfParamOrder(t *testing.T) {
+    a := NewKey("openai", "gpt-4", "hi", map[string]string{"temperature": "0.2", "top_p": "1"})
+    b := NewKey("openai", "gpt-4", "hi", map[string]string{"top_p": "1", "temperature": "0.2"})
+    if a != b {
+        t.Fatalf("NewKey should be stable regardless of map iteration order: %v != %v", a, b)
+    }
+}
+
+func TestNewKeyDiffersOnInput(t *testing.T) {
+    a := NewKey("openai", "gpt-4", "hi", nil)
+    b := NewKey("anthropic", "gpt-4", "hi", nil)
+    if a == b {
+        t.Fatal("NewKey should differ when the provider differs")
+    }
+}
+
+func TestMemoryCacheGetSet(t *testing.T) {
+    c := NewMemoryCache(0)
+    ctx := context.Background()
+    key := NewKey("openai", "", "hi", nil)
+
+    if _, found, err := c.Get(ctx, key); err != nil || found {
+        t.Fatalf("expected a miss before Set, found=%v err=%v", found, err)
+    }
+    if err := c.Set(ctx, key, "answer", 0); err != nil {
+        t.Fatalf("Set failed: %v", err)
+    }
+    value, found, err := c.Get(ctx, key)
+    if err != nil || !found || value != "answer" {
+        t.Fatalf("Get() = %q, %v, %v, want \"answer\", true, nil", value, found, err)
+    }
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+    c := NewMemoryCache(0)
+    ctx := context.Background()
+    key := NewKey("openai", "", "hi", nil)
+
+    if err := c.Set(ctx, key, "answer", time.Nanosecond); err != nil {
+        t.Fatalf("Set failed: %v", err)
+    }
+    time.Sleep(time.Millisecond)
+    if _, found, _ := c.Get(ctx, key); found {
+        t.Fatal("expected the entry to have expired")
+    }
+}
+
+func TestMemoryCacheEvictsOldestOverMaxSize(t *testing.T) {
+    c := NewMemoryCache(2)
+    ctx := context.Background()
+    keyA := NewKey("a", "", "prompt", nil)
+    keyB := NewKey("b", "", "prompt", nil)
+    keyC := NewKey("c", "", "prompt", nil)
+
+    c.Set(ctx, keyA, "a", 0)
+    c.Set(ctx, keyB, "b", 0)
+    c.Set(ctx, keyC, "c", 0) // should evict keyA, the least recently used
+
+    if _, found, _ := c.Get(ctx, keyA); found {
+        t.Fatal("expected the oldest entry to have been evicted")
+    }
+    if _, found, _ := c.Get(ctx, keyB); !found {
+        t.Fatal("expected keyB to still be cached")
+    }
+    if _, found, _ := c.Get(ctx, keyC); !found {
+        t.Fatal("expected keyC to still be cached")
+    }
+}
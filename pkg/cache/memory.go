@@ -0,0 +1,93 @@
+package cache
+
+import (
+    "container/list"
+    "context"
+    "sync"
+    "time"
+)
+
+type memoryEntry struct {
+    key       Key
+    value     string
+    expiresAt time.Time // zero means no expiry
+}
+
+// MemoryCache is the default in-process LRU cache backend.
+type MemoryCache struct {
+    maxSize int
+
+    mu    sync.Mutex
+    order *list.List
+    items map[Key]*list.Element
+}
+
+// NewMemoryCache returns an LRU cache holding at most maxSize entries
+// (0 means unbounded).
+func NewMemoryCache(maxSize int) *MemoryCache {
+    return &MemoryCache{
+        maxSize: maxSize,
+        order:   list.New(),
+        items:   make(map[Key]*list.Element),
+    }
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key Key) (string, bool, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    elem, ok := c.items[key]
+    if !ok {
+        return "", false, nil
+    }
+    entry := elem.Value.(*memoryEntry)
+    if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+        c.order.Remove(elem)
+        delete(c.items, key)
+        return "", false, nil
+    }
+
+    c.order.MoveToFront(elem)
+    return entry.value, true, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key Key, value string, ttl time.Duration) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    var expiresAt time.Time
+    if ttl > 0 {
+        expiresAt = time.Now().Add(ttl)
+    }
+
+    if elem, ok := c.items[key]; ok {
+        entry := elem.Value.(*memoryEntry)
+        entry.value = value
+        entry.expiresAt = expiresAt
+        c.order.MoveToFront(elem)
+        return nil
+    }
+
+    elem := c.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+    c.items[key] = elem
+
+    if c.maxSize > 0 && c.order.Len() > c.maxSize {
+        oldest := c.order.Back()
+        if oldest != nil {
+            c.order.Remove(oldest)
+            delete(c.items, oldest.Value.(*memoryEntry).key)
+        }
+    }
+    return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, key Key) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if elem, ok := c.items[key]; ok {
+        c.order.Remove(elem)
+        delete(c.items, key)
+    }
+    return nil
+}
@@ -0,0 +1,173 @@
+package cache
+
+import (
+    "context"
+    "encoding/json"
+    "strings"
+    "sync/atomic"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/turboOrange/llmhub/pkg/providers"
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+// Provider is the subset of the main.Provider interface the cache
+// decorator needs. It lives here (rather than importing package main,
+// which Go disallows) and is satisfied structurally by every Provider
+// the CLI builds.
+type Provider interface {
+    Name() string
+    Enabled() bool
+    Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error)
+    QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error)
+}
+
+// Stats counts cache hits and misses across every wrapped provider, so
+// the CLI can log an aggregate hit ratio.
+type Stats struct {
+    hits   int64
+    misses int64
+}
+
+func (s *Stats) hit()  { atomic.AddInt64(&s.hits, 1) }
+func (s *Stats) miss() { atomic.AddInt64(&s.misses, 1) }
+
+// Snapshot returns the current hit/miss counts.
+func (s *Stats) Snapshot() (hits, misses int64) {
+    return atomic.LoadInt64(&s.hits), atomic.LoadInt64(&s.misses)
+}
+
+// Ratio returns hits/(hits+misses), or 0 if nothing has been queried
+// yet.
+func (s *Stats) Ratio() float64 {
+    hits, misses := s.Snapshot()
+    total := hits + misses
+    if total == 0 {
+        return 0
+    }
+    return float64(hits) / float64(total)
+}
+
+// cachedAnswer is the JSON payload stored under a cache key, shared by
+// Query and QueryStream so warming the cache via one path (e.g.
+// --cache-warm, which calls Query) serves hits on the other (the router's
+// QueryStream-only fan-out/failover path).
+type cachedAnswer struct {
+    Content string               `json:"content"`
+    Usage   providers.TokenUsage `json:"usage"`
+}
+
+// cachingProvider wraps a Provider so both Query and QueryStream answers
+// are served from c when available, and stored back into c on miss.
+type cachingProvider struct {
+    Provider
+    cache  Cache
+    ttl    time.Duration
+    stats  *Stats
+    logger *zap.Logger
+}
+
+// Wrap returns p with its Query calls memoized in c using ttl, with
+// hit/miss counts tallied into stats.
+func Wrap(p Provider, c Cache, ttl time.Duration, stats *Stats, logger *zap.Logger) Provider {
+    return &cachingProvider{Provider: p, cache: c, ttl: ttl, stats: stats, logger: logger}
+}
+
+func (p *cachingProvider) Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error) {
+    key := NewKey(p.Name(), extra["model"], prompt, extra)
+
+    if ans, found := p.getCached(ctx, key); found {
+        return tools.Response{Content: ans.Content, FinishReason: "stop"}, nil
+    }
+
+    resp, err := p.Provider.Query(ctx, prompt, extra, toolList)
+    if err != nil {
+        return resp, err
+    }
+    // Only cache final answers; a response awaiting tool-call results
+    // isn't safe to replay for a future identical prompt.
+    if len(resp.ToolCalls) == 0 {
+        p.setCached(ctx, key, cachedAnswer{Content: resp.Content})
+    }
+    return resp, nil
+}
+
+// QueryStream is consulted the same way Query is: queryProviders/
+// router.Route only ever call QueryStream (never Query) when actually
+// answering a prompt, so caching just Query left that path always
+// missing. On a hit the cached answer is replayed as a single text
+// chunk followed by a synthetic "stop" chunk; on a miss, the upstream
+// stream is drained, assembled, and cached under the same key Query
+// uses (so e.g. --cache-warm populates entries this path can serve).
+func (p *cachingProvider) QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error) {
+    key := NewKey(p.Name(), extra["model"], prompt, extra)
+
+    if ans, found := p.getCached(ctx, key); found {
+        out := make(chan providers.Chunk, 2)
+        out <- providers.Chunk{Text: ans.Content}
+        out <- providers.Chunk{FinishReason: "stop", Usage: ans.Usage}
+        close(out)
+        return out, nil
+    }
+
+    upstream, err := p.Provider.QueryStream(ctx, prompt, extra)
+    if err != nil {
+        return nil, err
+    }
+
+    out := make(chan providers.Chunk)
+    go func() {
+        defer close(out)
+        var content strings.Builder
+        var usage providers.TokenUsage
+        failed := false
+        for chunk := range upstream {
+            out <- chunk
+            if chunk.Err != nil {
+                failed = true
+                continue
+            }
+            content.WriteString(chunk.Text)
+            if chunk.FinishReason != "" {
+                usage = chunk.Usage
+            }
+        }
+        if !failed {
+            p.setCached(ctx, key, cachedAnswer{Content: content.String(), Usage: usage})
+        }
+    }()
+    return out, nil
+}
+
+// getCached looks up key, reporting a hit/miss to stats and returning the
+// decoded answer if present and valid.
+func (p *cachingProvider) getCached(ctx context.Context, key Key) (cachedAnswer, bool) {
+    raw, found, err := p.cache.Get(ctx, key)
+    if err != nil || !found {
+        p.stats.miss()
+        return cachedAnswer{}, false
+    }
+    var ans cachedAnswer
+    if err := json.Unmarshal([]byte(raw), &ans); err != nil {
+        p.logger.Warn("Failed to decode cache entry", zap.String("provider", p.Name()), zap.Error(err))
+        p.stats.miss()
+        return cachedAnswer{}, false
+    }
+    p.stats.hit()
+    p.logger.Debug("Cache hit", zap.String("provider", p.Name()))
+    return ans, true
+}
+
+// setCached JSON-encodes ans and writes it under key.
+func (p *cachingProvider) setCached(ctx context.Context, key Key, ans cachedAnswer) {
+    raw, err := json.Marshal(ans)
+    if err != nil {
+        p.logger.Warn("Failed to encode cache entry", zap.String("provider", p.Name()), zap.Error(err))
+        return
+    }
+    if err := p.cache.Set(ctx, key, string(raw), p.ttl); err != nil {
+        p.logger.Warn("Failed to write cache entry", zap.String("provider", p.Name()), zap.Error(err))
+    }
+}
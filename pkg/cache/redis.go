@@ -0,0 +1,45 @@
+package cache
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// RedisCache shares entries across every llmhub process pointed at the
+// same Redis instance, unlike MemoryCache or BoltCache.
+type RedisCache struct {
+    client *redis.Client
+}
+
+// NewRedisCache returns a cache backed by the Redis instance at addr
+// (host:port).
+func NewRedisCache(addr string) *RedisCache {
+    return &RedisCache{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key Key) (string, bool, error) {
+    value, err := c.client.Get(ctx, string(key)).Result()
+    if errors.Is(err, redis.Nil) {
+        return "", false, nil
+    }
+    if err != nil {
+        return "", false, err
+    }
+    return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key Key, value string, ttl time.Duration) error {
+    return c.client.Set(ctx, string(key), value, ttl).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, key Key) error {
+    return c.client.Del(ctx, string(key)).Err()
+}
+
+// Close releases the underlying Redis client's connections.
+func (c *RedisCache) Close() error {
+    return c.client.Close()
+}
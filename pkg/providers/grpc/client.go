@@ -0,0 +1,112 @@
+package grpc
+
+import (
+    "context"
+    "fmt"
+    "io"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials/insecure"
+
+    "github.com/turboOrange/llmhub/pkg/providers"
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+// GRPCProviderClient implements the llmhub Provider interface by
+// forwarding Query calls to a plugin binary over gRPC. The plugin can be
+// a long-running daemon or a process started on demand by a Registry.
+type GRPCProviderClient struct {
+    name    string
+    enabled bool
+    address string
+
+    conn   *grpc.ClientConn
+    client ProviderClient
+}
+
+// NewGRPCProviderClient dials the given address (e.g. "unix:///tmp/myllm.sock"
+// or "localhost:9000") and returns a Provider backed by the plugin there.
+func NewGRPCProviderClient(name, address string, enabled bool) (*GRPCProviderClient, error) {
+    conn, err := grpc.NewClient(
+        address,
+        grpc.WithTransportCredentials(insecure.NewCredentials()),
+        grpc.WithDefaultCallOptions(grpc.CallContentSubtype(jsonCodecName)),
+    )
+    if err != nil {
+        return nil, fmt.Errorf("dial provider plugin %q at %q: %w", name, address, err)
+    }
+    return &GRPCProviderClient{
+        name:    name,
+        enabled: enabled,
+        address: address,
+        conn:    conn,
+        client:  NewProviderClient(conn),
+    }, nil
+}
+
+func (g *GRPCProviderClient) Name() string  { return g.name }
+func (g *GRPCProviderClient) Enabled() bool { return g.enabled }
+
+// Query implements the llmhub Provider interface by delegating to the
+// plugin's Query RPC. The plugin wire protocol doesn't carry tool
+// schemas yet, so toolList is accepted for interface compatibility but
+// ignored; plugins always return a plain Content answer.
+func (g *GRPCProviderClient) Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error) {
+    resp, err := g.client.Query(ctx, &QueryRequest{Prompt: prompt, Extra: extra})
+    if err != nil {
+        return tools.Response{}, fmt.Errorf("provider plugin %q: %w", g.name, err)
+    }
+    return tools.Response{Content: resp.Answer, FinishReason: "stop"}, nil
+}
+
+// QueryStream implements the llmhub Provider interface by delegating to
+// the plugin's Stream RPC, translating each wire QueryChunk into a
+// providers.Chunk as it arrives.
+func (g *GRPCProviderClient) QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error) {
+    stream, err := g.client.Stream(ctx, &QueryRequest{Prompt: prompt, Extra: extra})
+    if err != nil {
+        return nil, fmt.Errorf("provider plugin %q: %w", g.name, err)
+    }
+
+    out := make(chan providers.Chunk)
+    go func() {
+        defer close(out)
+        for {
+            chunk, err := stream.Recv()
+            if err == io.EOF {
+                return
+            }
+            if err != nil {
+                out <- providers.Chunk{Err: fmt.Errorf("provider plugin %q: %w", g.name, err)}
+                return
+            }
+            out <- providers.Chunk{
+                Text:         chunk.Text,
+                FinishReason: chunk.FinishReason,
+                Usage: providers.TokenUsage{
+                    Prompt:     int(chunk.Usage.Prompt),
+                    Completion: int(chunk.Usage.Completion),
+                    Total:      int(chunk.Usage.Total),
+                },
+            }
+        }
+    }()
+    return out, nil
+}
+
+// Health pings the plugin's Health RPC.
+func (g *GRPCProviderClient) Health(ctx context.Context) error {
+    resp, err := g.client.Health(ctx, &HealthRequest{})
+    if err != nil {
+        return fmt.Errorf("provider plugin %q: %w", g.name, err)
+    }
+    if !resp.Ok {
+        return fmt.Errorf("provider plugin %q unhealthy: %s", g.name, resp.Detail)
+    }
+    return nil
+}
+
+// Close tears down the underlying gRPC connection.
+func (g *GRPCProviderClient) Close() error {
+    return g.conn.Close()
+}
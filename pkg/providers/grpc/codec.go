@@ -0,0 +1,35 @@
+package grpc
+
+import (
+    "encoding/json"
+
+    "google.golang.org/grpc/encoding"
+)
+
+// jsonCodecName is the content-subtype registered for jsonCodec below and
+// forced on every call made through NewGRPCProviderClient via
+// grpc.CallContentSubtype.
+const jsonCodecName = "llmhubjson"
+
+func init() {
+    encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec is a grpc encoding.Codec that marshals the plain structs in
+// provider.pb.go as JSON on the wire. protoc/protoc-gen-go aren't
+// available in this build environment, so those structs are hand-written
+// rather than real generated proto.Message implementations, and grpc-go's
+// default codec can't marshal them ("message is *grpc.QueryRequest, want
+// proto.Message"). Registering this codec and selecting it for every RPC
+// sidesteps that requirement entirely.
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return jsonCodecName }
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+    return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+    return json.Unmarshal(data, v)
+}
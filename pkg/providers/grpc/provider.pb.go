@@ -0,0 +1,52 @@
+// Hand-written companion to provider.proto: protoc/protoc-gen-go aren't
+// available in this build environment, so these message types are plain
+// Go structs rather than real protoc-gen-go output, and they do not
+// implement proto.Message. They're wire-compatible only with the jsonCodec
+// registered in codec.go, which every GRPCProviderClient call forces via
+// grpc.CallContentSubtype — not with grpc-go's default protobuf codec.
+
+package grpc
+
+// QueryRequest is the request message for Query and Stream.
+type QueryRequest struct {
+    Prompt string
+    Extra  map[string]string
+}
+
+// QueryResponse is the response message for Query.
+type QueryResponse struct {
+    Answer string
+}
+
+// QueryChunk is a single piece of a streamed Query response. FinishReason
+// is empty until the final chunk.
+type QueryChunk struct {
+    Text         string
+    FinishReason string
+    Usage        TokenUsage
+}
+
+// TokenUsage mirrors providers.TokenUsage on the wire.
+type TokenUsage struct {
+    Prompt     int32
+    Completion int32
+    Total      int32
+}
+
+// HealthRequest is the request message for Health.
+type HealthRequest struct{}
+
+// HealthResponse is the response message for Health.
+type HealthResponse struct {
+    Ok     bool
+    Detail string
+}
+
+// CapabilitiesRequest is the request message for Capabilities.
+type CapabilitiesRequest struct{}
+
+// CapabilitiesResponse is the response message for Capabilities.
+type CapabilitiesResponse struct {
+    Streaming bool
+    ToolCalls bool
+}
@@ -0,0 +1,182 @@
+// Hand-written companion to provider.proto: protoc/protoc-gen-go-grpc
+// aren't available in this build environment, so this client/server
+// plumbing is written by hand rather than generated. It relies on the
+// jsonCodec registered in codec.go for marshaling instead of real
+// protobuf wire encoding.
+
+package grpc
+
+import (
+    "context"
+
+    "google.golang.org/grpc"
+)
+
+// ProviderClient is the client API for the Provider service.
+type ProviderClient interface {
+    Query(ctx context.Context, in *QueryRequest) (*QueryResponse, error)
+    Stream(ctx context.Context, in *QueryRequest) (ProviderStreamClient, error)
+    Health(ctx context.Context, in *HealthRequest) (*HealthResponse, error)
+    Capabilities(ctx context.Context, in *CapabilitiesRequest) (*CapabilitiesResponse, error)
+}
+
+// ProviderStreamClient is returned by Stream and yields QueryChunks until
+// the plugin marks one as Done (or the stream errors out).
+type ProviderStreamClient interface {
+    Recv() (*QueryChunk, error)
+}
+
+type providerClient struct {
+    cc *grpc.ClientConn
+}
+
+// NewProviderClient wraps an existing gRPC connection in a ProviderClient.
+func NewProviderClient(cc *grpc.ClientConn) ProviderClient {
+    return &providerClient{cc: cc}
+}
+
+func (c *providerClient) Query(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
+    out := new(QueryResponse)
+    if err := c.cc.Invoke(ctx, "/providers.Provider/Query", in, out); err != nil {
+        return nil, err
+    }
+    return out, nil
+}
+
+func (c *providerClient) Stream(ctx context.Context, in *QueryRequest) (ProviderStreamClient, error) {
+    stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "Stream", ServerStreams: true}, "/providers.Provider/Stream")
+    if err != nil {
+        return nil, err
+    }
+    if err := stream.SendMsg(in); err != nil {
+        return nil, err
+    }
+    if err := stream.CloseSend(); err != nil {
+        return nil, err
+    }
+    return &providerStreamClient{stream}, nil
+}
+
+func (c *providerClient) Health(ctx context.Context, in *HealthRequest) (*HealthResponse, error) {
+    out := new(HealthResponse)
+    if err := c.cc.Invoke(ctx, "/providers.Provider/Health", in, out); err != nil {
+        return nil, err
+    }
+    return out, nil
+}
+
+func (c *providerClient) Capabilities(ctx context.Context, in *CapabilitiesRequest) (*CapabilitiesResponse, error) {
+    out := new(CapabilitiesResponse)
+    if err := c.cc.Invoke(ctx, "/providers.Provider/Capabilities", in, out); err != nil {
+        return nil, err
+    }
+    return out, nil
+}
+
+type providerStreamClient struct {
+    grpc.ClientStream
+}
+
+func (x *providerStreamClient) Recv() (*QueryChunk, error) {
+    m := new(QueryChunk)
+    if err := x.ClientStream.RecvMsg(m); err != nil {
+        return nil, err
+    }
+    return m, nil
+}
+
+// ProviderServer is the server API for the Provider service. Plugin
+// binaries implement this and register it with grpc.NewServer.
+type ProviderServer interface {
+    Query(ctx context.Context, in *QueryRequest) (*QueryResponse, error)
+    Stream(in *QueryRequest, stream ProviderStreamServer) error
+    Health(ctx context.Context, in *HealthRequest) (*HealthResponse, error)
+    Capabilities(ctx context.Context, in *CapabilitiesRequest) (*CapabilitiesResponse, error)
+}
+
+// ProviderStreamServer is the server-side half of a Stream call.
+type ProviderStreamServer interface {
+    Send(*QueryChunk) error
+    grpc.ServerStream
+}
+
+type providerStreamServer struct {
+    grpc.ServerStream
+}
+
+func (x *providerStreamServer) Send(m *QueryChunk) error {
+    return x.ServerStream.SendMsg(m)
+}
+
+// RegisterProviderServer registers srv as the Provider service
+// implementation on s. Plugin binaries call this after grpc.NewServer.
+func RegisterProviderServer(s *grpc.Server, srv ProviderServer) {
+    s.RegisterService(&providerServiceDesc, srv)
+}
+
+func queryHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+    in := new(QueryRequest)
+    if err := dec(in); err != nil {
+        return nil, err
+    }
+    if interceptor == nil {
+        return srv.(ProviderServer).Query(ctx, in)
+    }
+    info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/providers.Provider/Query"}
+    handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+        return srv.(ProviderServer).Query(ctx, req.(*QueryRequest))
+    }
+    return interceptor(ctx, in, info, handler)
+}
+
+func healthHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+    in := new(HealthRequest)
+    if err := dec(in); err != nil {
+        return nil, err
+    }
+    if interceptor == nil {
+        return srv.(ProviderServer).Health(ctx, in)
+    }
+    info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/providers.Provider/Health"}
+    handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+        return srv.(ProviderServer).Health(ctx, req.(*HealthRequest))
+    }
+    return interceptor(ctx, in, info, handler)
+}
+
+func capabilitiesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+    in := new(CapabilitiesRequest)
+    if err := dec(in); err != nil {
+        return nil, err
+    }
+    if interceptor == nil {
+        return srv.(ProviderServer).Capabilities(ctx, in)
+    }
+    info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/providers.Provider/Capabilities"}
+    handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+        return srv.(ProviderServer).Capabilities(ctx, req.(*CapabilitiesRequest))
+    }
+    return interceptor(ctx, in, info, handler)
+}
+
+func streamHandler(srv interface{}, stream grpc.ServerStream) error {
+    in := new(QueryRequest)
+    if err := stream.RecvMsg(in); err != nil {
+        return err
+    }
+    return srv.(ProviderServer).Stream(in, &providerStreamServer{stream})
+}
+
+var providerServiceDesc = grpc.ServiceDesc{
+    ServiceName: "providers.Provider",
+    HandlerType: (*ProviderServer)(nil),
+    Methods: []grpc.MethodDesc{
+        {MethodName: "Query", Handler: queryHandler},
+        {MethodName: "Health", Handler: healthHandler},
+        {MethodName: "Capabilities", Handler: capabilitiesHandler},
+    },
+    Streams: []grpc.StreamDesc{
+        {StreamName: "Stream", Handler: streamHandler, ServerStreams: true},
+    },
+    Metadata: "provider.proto",
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+    "bufio"
+    "fmt"
+    "os/exec"
+    "sync"
+
+    "go.uber.org/zap"
+)
+
+// PluginConfig describes how to reach a single provider plugin, as read
+// from the "providers" block of config.json.
+type PluginConfig struct {
+    // Address is the socket or host:port the plugin listens on, e.g.
+    // "unix:///tmp/myllm.sock" or "localhost:9001".
+    Address string `json:"address"`
+    // Command, if set, is spawned on demand and expected to start
+    // serving on Address. Leave empty to dial an already-running plugin.
+    Command string   `json:"command"`
+    Args    []string `json:"args"`
+}
+
+// Registry starts provider-plugin child processes on demand, forwards
+// their stdout/stderr to the llmhub logger, and reaps them on Shutdown.
+type Registry struct {
+    logger *zap.Logger
+
+    mu        sync.Mutex
+    processes map[string]*exec.Cmd
+}
+
+// NewRegistry returns a Registry that logs through logger.
+func NewRegistry(logger *zap.Logger) *Registry {
+    return &Registry{
+        logger:    logger,
+        processes: make(map[string]*exec.Cmd),
+    }
+}
+
+// Start launches the plugin for name if PluginConfig.Command is set and
+// it isn't already running, then returns a client dialed to its address.
+func (r *Registry) Start(name string, cfg PluginConfig, enabled bool) (*GRPCProviderClient, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if cfg.Command != "" {
+        if _, running := r.processes[name]; !running {
+            cmd := exec.Command(cfg.Command, cfg.Args...)
+
+            stdout, err := cmd.StdoutPipe()
+            if err != nil {
+                return nil, fmt.Errorf("provider plugin %q: stdout pipe: %w", name, err)
+            }
+            stderr, err := cmd.StderrPipe()
+            if err != nil {
+                return nil, fmt.Errorf("provider plugin %q: stderr pipe: %w", name, err)
+            }
+
+            if err := cmd.Start(); err != nil {
+                return nil, fmt.Errorf("provider plugin %q: start: %w", name, err)
+            }
+            r.processes[name] = cmd
+
+            go r.forwardLogs(name, "stdout", stdout)
+            go r.forwardLogs(name, "stderr", stderr)
+
+            r.logger.Info("Started provider plugin", zap.String("provider", name), zap.Int("pid", cmd.Process.Pid))
+        }
+    }
+
+    return NewGRPCProviderClient(name, cfg.Address, enabled)
+}
+
+func (r *Registry) forwardLogs(name, stream string, pipe interface{ Read([]byte) (int, error) }) {
+    scanner := bufio.NewScanner(pipe)
+    for scanner.Scan() {
+        r.logger.Info("plugin output", zap.String("provider", name), zap.String("stream", stream), zap.String("line", scanner.Text()))
+    }
+}
+
+// Shutdown terminates every child process started by this registry.
+func (r *Registry) Shutdown() {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    for name, cmd := range r.processes {
+        if cmd.Process == nil {
+            continue
+        }
+        if err := cmd.Process.Kill(); err != nil {
+            r.logger.Warn("Failed to kill provider plugin", zap.String("provider", name), zap.Error(err))
+            continue
+        }
+        if err := cmd.Wait(); err != nil {
+            r.logger.Debug("Provider plugin exited", zap.String("provider", name), zap.Error(err))
+        }
+    }
+    r.processes = make(map[string]*exec.Cmd)
+}
@@ -0,0 +1,23 @@
+// Package providers holds types shared by every Provider implementation
+// (built-in and plugin) so they don't need to depend on package main.
+package providers
+
+// TokenUsage reports how many tokens a single Query/QueryStream call
+// consumed, when the backend exposes that information.
+type TokenUsage struct {
+    Prompt     int
+    Completion int
+    Total      int
+}
+
+// Chunk is one piece of a streamed response. FinishReason is empty until
+// the final chunk, at which point Usage (if known) is also populated. Err
+// carries a mid-stream failure (e.g. the plugin connection dropped); when
+// set, it is the final value sent on the channel and Text/FinishReason
+// should be ignored.
+type Chunk struct {
+    Text         string
+    FinishReason string
+    Usage        TokenUsage
+    Err          error
+}
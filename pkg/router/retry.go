@@ -0,0 +1,28 @@
+package router
+
+import (
+    "context"
+    "errors"
+    "time"
+)
+
+// isRetryable reports whether a failed Query is worth retrying against
+// the same provider. Context cancellation/deadlines are never retried;
+// everything else is assumed transient (timeouts, connection resets)
+// since providers don't yet distinguish error classes.
+func isRetryable(err error) bool {
+    return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// backoff returns the delay before retry attempt n (0-indexed),
+// doubling from 200ms and capping at 5s.
+func backoff(attempt int) time.Duration {
+    d := 200 * time.Millisecond
+    for i := 0; i < attempt; i++ {
+        d *= 2
+        if d > 5*time.Second {
+            return 5 * time.Second
+        }
+    }
+    return d
+}
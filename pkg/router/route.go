@@ -0,0 +1,121 @@
+package router
+
+import (
+    "context"
+    "strings"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/turboOrange/llmhub/pkg/providers"
+)
+
+// ChunkFunc is called for every streamed chunk as it arrives, so callers
+// can print partial output the way queryProviders used to.
+type ChunkFunc func(provider string, chunk providers.Chunk)
+
+// Route selects provider(s) from provs according to the router's policy
+// and queries them using strategy:
+//
+//   - StrategyFanout queries every selected provider concurrently and
+//     returns every answer (and error), same as querying everyone.
+//   - StrategyFailover tries providers one at a time in policy order,
+//     retrying transient errors with backoff up to cfg.MaxRetries times
+//     before falling back to the next provider.
+func (r *Router) Route(ctx context.Context, prompt string, provs []Provider, strategy Strategy, onChunk ChunkFunc) (map[string]string, map[string]providers.TokenUsage, map[string]error) {
+    ordered := r.order(provs)
+
+    if strategy == StrategyFailover {
+        return r.routeFailover(ctx, prompt, ordered, onChunk)
+    }
+    return r.routeFanout(ctx, prompt, ordered, onChunk)
+}
+
+func (r *Router) routeFanout(ctx context.Context, prompt string, ordered []Provider, onChunk ChunkFunc) (map[string]string, map[string]providers.TokenUsage, map[string]error) {
+    var wg sync.WaitGroup
+    var mu sync.Mutex
+    results := make(map[string]string)
+    usage := make(map[string]providers.TokenUsage)
+    errs := make(map[string]error)
+
+    for _, p := range ordered {
+        wg.Add(1)
+        go func(prov Provider) {
+            defer wg.Done()
+            start := time.Now()
+            text, u, err := r.consumeStream(ctx, prov, prompt, onChunk)
+            r.recordLatency(prov.Name(), float64(time.Since(start).Milliseconds()))
+
+            mu.Lock()
+            defer mu.Unlock()
+            if err != nil {
+                r.logger.Error("Provider failed", zap.String("provider", prov.Name()), zap.Error(err))
+                errs[prov.Name()] = err
+                return
+            }
+            results[prov.Name()] = text
+            usage[prov.Name()] = u
+        }(p)
+    }
+    wg.Wait()
+    return results, usage, errs
+}
+
+func (r *Router) routeFailover(ctx context.Context, prompt string, ordered []Provider, onChunk ChunkFunc) (map[string]string, map[string]providers.TokenUsage, map[string]error) {
+    errs := make(map[string]error)
+
+    for _, p := range ordered {
+        for attempt := 0; attempt <= r.cfg.MaxRetries; attempt++ {
+            start := time.Now()
+            text, u, err := r.consumeStream(ctx, p, prompt, onChunk)
+            r.recordLatency(p.Name(), float64(time.Since(start).Milliseconds()))
+
+            if err == nil {
+                return map[string]string{p.Name(): text}, map[string]providers.TokenUsage{p.Name(): u}, errs
+            }
+
+            errs[p.Name()] = err
+            if !isRetryable(err) {
+                r.logger.Warn("Provider returned non-retryable error, falling back", zap.String("provider", p.Name()), zap.Error(err))
+                break
+            }
+            if attempt < r.cfg.MaxRetries {
+                r.logger.Warn("Provider failed, retrying", zap.String("provider", p.Name()), zap.Int("attempt", attempt+1), zap.Error(err))
+                select {
+                case <-time.After(backoff(attempt)):
+                case <-ctx.Done():
+                    errs[p.Name()] = ctx.Err()
+                    return map[string]string{}, map[string]providers.TokenUsage{}, errs
+                }
+            }
+        }
+    }
+    return map[string]string{}, map[string]providers.TokenUsage{}, errs
+}
+
+// consumeStream drains a single QueryStream call into its full text and
+// final usage, reporting a mid-stream error (a chunk with Err set) as a
+// normal error.
+func (r *Router) consumeStream(ctx context.Context, p Provider, prompt string, onChunk ChunkFunc) (string, providers.TokenUsage, error) {
+    stream, err := p.QueryStream(ctx, prompt, nil)
+    if err != nil {
+        return "", providers.TokenUsage{}, err
+    }
+
+    var sb strings.Builder
+    var usage providers.TokenUsage
+    for chunk := range stream {
+        if onChunk != nil {
+            onChunk(p.Name(), chunk)
+        }
+        if chunk.Err != nil {
+            return sb.String(), usage, chunk.Err
+        }
+        sb.WriteString(chunk.Text)
+        if chunk.FinishReason != "" {
+            usage = chunk.Usage
+        }
+    }
+    return sb.String(), usage, nil
+}
@@ -0,0 +1,114 @@
+// Package router sits between main and the providers: given a prompt it
+// picks which provider(s) to query based on a routing policy, retries
+// transient failures with backoff, and falls back to the next provider
+// in priority order on non-retryable errors.
+package router
+
+import (
+    "context"
+    "sync"
+
+    "go.uber.org/zap"
+
+    "github.com/turboOrange/llmhub/pkg/providers"
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+// Provider is the subset of the main.Provider interface the router
+// needs. It lives here (rather than importing package main, which Go
+// disallows) and is satisfied structurally by every Provider the CLI
+// builds.
+type Provider interface {
+    Name() string
+    Enabled() bool
+    Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error)
+    QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error)
+}
+
+// Policy decides which provider(s) a Route call selects.
+type Policy string
+
+const (
+    PolicyPriority     Policy = "priority"
+    PolicyRoundRobin   Policy = "round-robin"
+    PolicyWeighted     Policy = "weighted"
+    PolicyLeastLatency Policy = "least-latency"
+)
+
+// Strategy decides how selected providers are queried.
+type Strategy string
+
+const (
+    // StrategyFanout queries every selected provider concurrently and
+    // returns all the answers, same as the original "query everyone".
+    StrategyFanout Strategy = "fanout"
+    // StrategyFailover tries providers one at a time in policy order,
+    // falling back to the next on non-retryable error.
+    StrategyFailover Strategy = "failover"
+)
+
+// ProviderRoute configures one provider's place in the routing table.
+type ProviderRoute struct {
+    Name           string `json:"name"`
+    Model          string `json:"model"`
+    Weight         int    `json:"weight"`
+    TimeoutSeconds int    `json:"timeout_seconds"`
+}
+
+// Config is the "routing:" block of config.json.
+type Config struct {
+    Policy     Policy          `json:"policy"`
+    MaxRetries int             `json:"max_retries"`
+    Providers  []ProviderRoute `json:"providers"`
+}
+
+// Router selects and queries providers according to Config.
+type Router struct {
+    cfg    Config
+    logger *zap.Logger
+
+    mu        sync.Mutex
+    rrCursor  int
+    latencies map[string]float64 // exponential moving average, milliseconds
+}
+
+// New returns a Router for cfg. An empty cfg falls back to
+// PolicyPriority with the providers in whatever order they're passed to
+// Route, so routing is optional in config.json.
+func New(cfg Config, logger *zap.Logger) *Router {
+    if cfg.Policy == "" {
+        cfg.Policy = PolicyPriority
+    }
+    if cfg.MaxRetries < 0 {
+        cfg.MaxRetries = 0
+    }
+    return &Router{
+        cfg:       cfg,
+        logger:    logger,
+        latencies: make(map[string]float64),
+    }
+}
+
+// Latencies returns the current exponential-moving-average latency (in
+// milliseconds) recorded for every provider the router has queried so
+// far, for callers that want to report per-provider timing.
+func (r *Router) Latencies() map[string]float64 {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    out := make(map[string]float64, len(r.latencies))
+    for name, ms := range r.latencies {
+        out[name] = ms
+    }
+    return out
+}
+
+// routeFor looks up the configured route for a provider, if any.
+func (r *Router) routeFor(name string) (ProviderRoute, bool) {
+    for _, pr := range r.cfg.Providers {
+        if pr.Name == name {
+            return pr, true
+        }
+    }
+    return ProviderRoute{}, false
+}
@@ -0,0 +1,124 @@
+package router
+
+import (
+    "math/rand"
+    "sort"
+)
+
+// order returns provs arranged according to the router's policy. For
+// PolicyWeighted and PolicyRoundRobin the first element is the pick;
+// failover mode still tries the rest in the returned order if it fails.
+func (r *Router) order(provs []Provider) []Provider {
+    switch r.cfg.Policy {
+    case PolicyRoundRobin:
+        return r.orderRoundRobin(provs)
+    case PolicyWeighted:
+        return r.orderWeighted(provs)
+    case PolicyLeastLatency:
+        return r.orderLeastLatency(provs)
+    default: // PolicyPriority
+        return r.orderPriority(provs)
+    }
+}
+
+// orderPriority sorts by the index of each provider in cfg.Providers;
+// providers with no configured route keep their relative input order at
+// the end.
+func (r *Router) orderPriority(provs []Provider) []Provider {
+    rank := make(map[string]int, len(r.cfg.Providers))
+    for i, pr := range r.cfg.Providers {
+        rank[pr.Name] = i
+    }
+    ordered := append([]Provider{}, provs...)
+    sort.SliceStable(ordered, func(i, j int) bool {
+        ri, iok := rank[ordered[i].Name()]
+        rj, jok := rank[ordered[j].Name()]
+        if iok && jok {
+            return ri < rj
+        }
+        return iok && !jok
+    })
+    return ordered
+}
+
+// orderRoundRobin rotates the provider list so each call starts one
+// position further along, spreading load evenly over time.
+func (r *Router) orderRoundRobin(provs []Provider) []Provider {
+    if len(provs) == 0 {
+        return provs
+    }
+    r.mu.Lock()
+    start := r.rrCursor % len(provs)
+    r.rrCursor++
+    r.mu.Unlock()
+
+    ordered := make([]Provider, 0, len(provs))
+    ordered = append(ordered, provs[start:]...)
+    ordered = append(ordered, provs[:start]...)
+    return ordered
+}
+
+// orderWeighted picks a random order where providers with a higher
+// configured Weight are more likely to come first.
+func (r *Router) orderWeighted(provs []Provider) []Provider {
+    remaining := append([]Provider{}, provs...)
+    ordered := make([]Provider, 0, len(provs))
+    for len(remaining) > 0 {
+        total := 0
+        weights := make([]int, len(remaining))
+        for i, p := range remaining {
+            w := 1
+            if route, ok := r.routeFor(p.Name()); ok && route.Weight > 0 {
+                w = route.Weight
+            }
+            weights[i] = w
+            total += w
+        }
+        pick := rand.Intn(total)
+        idx := 0
+        for acc := weights[0]; acc <= pick; acc += weights[idx] {
+            idx++
+        }
+        ordered = append(ordered, remaining[idx])
+        remaining = append(remaining[:idx], remaining[idx+1:]...)
+    }
+    return ordered
+}
+
+// orderLeastLatency sorts by each provider's observed EWMA latency,
+// fastest first; providers never queried yet sort first so they get a
+// chance to report a real latency.
+func (r *Router) orderLeastLatency(provs []Provider) []Provider {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    ordered := append([]Provider{}, provs...)
+    sort.SliceStable(ordered, func(i, j int) bool {
+        li, iok := r.latencies[ordered[i].Name()]
+        lj, jok := r.latencies[ordered[j].Name()]
+        switch {
+        case !iok && !jok:
+            return false
+        case !iok:
+            return true
+        case !jok:
+            return false
+        default:
+            return li < lj
+        }
+    })
+    return ordered
+}
+
+// recordLatency updates the EWMA latency estimate used by
+// PolicyLeastLatency.
+func (r *Router) recordLatency(name string, ms float64) {
+    const alpha = 0.3
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if prev, ok := r.latencies[name]; ok {
+        r.latencies[name] = alpha*ms + (1-alpha)*prev
+    } else {
+        r.latencies[name] = ms
+    }
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+    "context"
+    "testing"
+
+    "go.uber.org/zap"
+
+    "github.com/turboOrange/llmhub/pkg/providers"
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+type fakeProvider struct {
+    name string
+}
+
+func (f *fakeProvider) Name() string  { return f.name }
+func (f *fakeProvider) Enabled() bool { return true }
+func (f *fakeProvider) Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error) {
+    return tools.Response{Content: f.name, FinishReason: "stop"}, nil
+}
+func (f *fakeProvider) QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error) {
+    out := make(chan providers.Chunk)
+    close(out)
+    return out, nil
+}
+
+func names(provs []Provider) []string {
+    out := make([]string, len(provs))
+    for i, p := range provs {
+        out[i] = p.Name()
+    }
+    return out
+}
+
+func TestOrderPriority(t *testing.T) {
+    r := New(Config{Policy: PolicyPriority, Providers: []ProviderRoute{{Name: "b"}, {Name: "a"}}}, zap.NewNop())
+    provs := []Provider{&fakeProvider{"a"}, &fakeProvider{"b"}, &fakeProvider{"c"}}
+
+    got := names(r.order(provs))
+    want := []string{"b", "a", "c"}
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("order() = %v, want %v", got, want)
+        }
+    }
+}
+
+func TestOrderRoundRobinRotates(t *testing.T) {
+    r := New(Config{Policy: PolicyRoundRobin}, zap.NewNop())
+    provs := []Provider{&fakeProvider{"a"}, &fakeProvider{"b"}, &fakeProvider{"c"}}
+
+    first := names(r.order(provs))
+    second := names(r.order(provs))
+    if first[0] == second[0] {
+        t.Fatalf("expected round-robin to rotate the starting provider, got %v then %v", first, second)
+    }
+}
+
+func TestOrderLeastLatencyUnseenFirst(t *testing.T) {
+    r := New(Config{Policy: PolicyLeastLatency}, zap.NewNop())
+    r.recordLatency("slow", 100)
+    r.recordLatency("fast", 10)
+    provs := []Provider{&fakeProvider{"slow"}, &fakeProvider{"fast"}, &fakeProvider{"unseen"}}
+
+    got := names(r.order(provs))
+    if got[0] != "unseen" {
+        t.Fatalf("expected an unseen provider to sort first, got %v", got)
+    }
+    if got[1] != "fast" || got[2] != "slow" {
+        t.Fatalf("expected fast before slow among seen providers, got %v", got)
+    }
+}
+
+func TestRecordLatencyEWMA(t *testing.T) {
+    r := New(Config{}, zap.NewNop())
+    r.recordLatency("p", 100)
+    r.recordLatency("p", 100)
+    got := r.Latencies()["p"]
+    if got != 100 {
+        t.Fatalf("Latencies()[p] = %v, want 100 once converged", got)
+    }
+}
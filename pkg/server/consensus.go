@@ -0,0 +1,103 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/turboOrange/llmhub/pkg/providers"
+    "github.com/turboOrange/llmhub/pkg/router"
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+// maxToolIterations bounds runAgentLoop the same way main.go's
+// summarizeAnswers bounds its own loop, so a model that keeps requesting
+// tool calls can't hang a request forever.
+const maxToolIterations = 5
+
+// runAgentLoop queries provider, executing any tool calls it requests
+// via s.registry and feeding the results back, until it returns a final
+// answer. This mirrors main.go's summarizeAnswers so the HTTP API gets
+// the same agent behavior as the CLI instead of a thinner one-shot call.
+func (s *Server) runAgentLoop(ctx context.Context, provider router.Provider, prompt string) (string, error) {
+    for i := 0; i < maxToolIterations; i++ {
+        resp, err := provider.Query(ctx, prompt, nil, s.toolList)
+        if err != nil {
+            return "", err
+        }
+        if resp.FinishReason != "tool_calls" || len(resp.ToolCalls) == 0 {
+            return resp.Content, nil
+        }
+
+        for _, call := range resp.ToolCalls {
+            msg, err := s.registry.Execute(ctx, call)
+            if err != nil {
+                s.logger.Warn("Tool call failed", zap.String("tool", call.Name), zap.Error(err))
+                msg = tools.ToolMessage{ToolCallID: call.ID, Content: fmt.Sprintf("error: %v", err)}
+            } else {
+                s.logger.Info("Executed tool call", zap.String("tool", call.Name))
+            }
+            prompt += fmt.Sprintf("[tool %s result]: %s\n", call.Name, msg.Content)
+        }
+    }
+    return "", fmt.Errorf("agent exceeded %d tool-call iterations", maxToolIterations)
+}
+
+// consensusAnswer queries every known provider concurrently and asks
+// one of them to summarize the results, mirroring the CLI's
+// query-all-then-summarize pipeline.
+func (s *Server) consensusAnswer(ctx context.Context, prompt string) (string, map[string]providers.TokenUsage, error) {
+    provs := make([]router.Provider, 0, len(s.names))
+    for _, name := range s.names {
+        provs = append(provs, s.providers[name])
+    }
+
+    results, usage, errs := s.rt.Route(ctx, prompt, provs, router.StrategyFanout, nil)
+    for name, err := range errs {
+        s.logger.Error("Provider failed", zap.String("provider", name), zap.Error(err))
+        s.metrics.ErrorsTotal.WithLabelValues(name).Inc()
+    }
+    if len(results) == 0 {
+        return "", usage, fmt.Errorf("no providers returned an answer")
+    }
+
+    summaryPrompt := "Summarize and provide a verdict for these answers:\n"
+    for name, answer := range results {
+        summaryPrompt += fmt.Sprintf("[%s]: %s\n", name, answer)
+    }
+
+    if len(s.names) == 0 {
+        return "", usage, fmt.Errorf("no summarizer provider available")
+    }
+    summarizer := s.providers[s.names[0]]
+
+    content, err := s.runAgentLoop(ctx, summarizer, summaryPrompt)
+    if err != nil {
+        s.metrics.ErrorsTotal.WithLabelValues(summarizer.Name()).Inc()
+        return "", usage, err
+    }
+    return content, usage, nil
+}
+
+// answer dispatches to consensusAnswer or a single named provider
+// depending on model.
+func (s *Server) answer(ctx context.Context, model, prompt string) (string, map[string]providers.TokenUsage, error) {
+    if model == "" || model == ConsensusModel {
+        return s.consensusAnswer(ctx, prompt)
+    }
+
+    p, ok := s.providers[model]
+    if !ok {
+        return "", nil, fmt.Errorf("unknown provider/model %q", model)
+    }
+    start := time.Now()
+    content, err := s.runAgentLoop(ctx, p, prompt)
+    s.metrics.ProviderLatency.WithLabelValues(model).Observe(time.Since(start).Seconds())
+    if err != nil {
+        s.metrics.ErrorsTotal.WithLabelValues(model).Inc()
+        return "", nil, err
+    }
+    return content, nil, nil
+}
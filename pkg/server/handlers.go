@@ -0,0 +1,106 @@
+package server
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+)
+
+func lastUserMessage(messages []chatMessage) string {
+    for i := len(messages) - 1; i >= 0; i-- {
+        if messages[i].Role == "user" {
+            return messages[i].Content
+        }
+    }
+    if len(messages) > 0 {
+        return messages[len(messages)-1].Content
+    }
+    return ""
+}
+
+func (s *Server) handleModels(w http.ResponseWriter, r *http.Request) {
+    s.metrics.RequestsTotal.WithLabelValues("/v1/models").Inc()
+
+    data := make([]modelPayload, 0, len(s.names)+1)
+    data = append(data, modelPayload{ID: ConsensusModel, Object: "model", OwnedBy: "llmhub"})
+    for _, name := range s.names {
+        data = append(data, modelPayload{ID: name, Object: "model", OwnedBy: "llmhub"})
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(modelsResponse{Object: "list", Data: data})
+}
+
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+    s.metrics.RequestsTotal.WithLabelValues("/v1/chat/completions").Inc()
+
+    var req chatCompletionRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+    prompt := lastUserMessage(req.Messages)
+
+    if req.Stream {
+        s.streamAnswer(r.Context(), w, req.Model, prompt)
+        return
+    }
+
+    content, usage, err := s.answer(r.Context(), req.Model, prompt)
+    if err != nil {
+        s.logger.Error("Chat completion failed", zap.String("model", req.Model), zap.Error(err))
+        http.Error(w, err.Error(), http.StatusBadGateway)
+        return
+    }
+
+    resp := chatCompletionResponse{
+        ID:      fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano()),
+        Object:  "chat.completion",
+        Created: time.Now().Unix(),
+        Model:   req.Model,
+        Choices: []chatCompletionChoice{{
+            Index:        0,
+            Message:      chatMessage{Role: "assistant", Content: content},
+            FinishReason: "stop",
+        }},
+        Usage: totalUsage(usage),
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) handleCompletions(w http.ResponseWriter, r *http.Request) {
+    s.metrics.RequestsTotal.WithLabelValues("/v1/completions").Inc()
+
+    var req completionRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    if req.Stream {
+        s.streamAnswer(r.Context(), w, req.Model, req.Prompt)
+        return
+    }
+
+    content, usage, err := s.answer(r.Context(), req.Model, req.Prompt)
+    if err != nil {
+        s.logger.Error("Completion failed", zap.String("model", req.Model), zap.Error(err))
+        http.Error(w, err.Error(), http.StatusBadGateway)
+        return
+    }
+
+    resp := completionResponse{
+        ID:      fmt.Sprintf("cmpl-%d", time.Now().UnixNano()),
+        Object:  "text_completion",
+        Created: time.Now().Unix(),
+        Model:   req.Model,
+        Choices: []completionChoice{{Index: 0, Text: content, FinishReason: "stop"}},
+        Usage:   totalUsage(usage),
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,42 @@
+package server
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics are the Prometheus series exposed at /metrics: request
+// volume, per-provider latency and errors, and tokens consumed.
+type Metrics struct {
+    Registry *prometheus.Registry
+
+    RequestsTotal   *prometheus.CounterVec
+    ProviderLatency *prometheus.HistogramVec
+    ErrorsTotal     *prometheus.CounterVec
+    TokensTotal     *prometheus.CounterVec
+}
+
+// NewMetrics registers and returns a fresh Metrics set against its own
+// registry, rather than prometheus's global DefaultRegisterer, so that
+// multiple Servers (e.g. one per test) can coexist in a process without
+// colliding on collector registration.
+func NewMetrics() *Metrics {
+    m := &Metrics{
+        Registry: prometheus.NewRegistry(),
+        RequestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "llmhub_requests_total",
+            Help: "Total HTTP requests handled by the llmhub server, by route.",
+        }, []string{"route"}),
+        ProviderLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+            Name: "llmhub_provider_latency_seconds",
+            Help: "Provider query latency in seconds, by provider.",
+        }, []string{"provider"}),
+        ErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "llmhub_provider_errors_total",
+            Help: "Provider query errors, by provider.",
+        }, []string{"provider"}),
+        TokensTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "llmhub_tokens_total",
+            Help: "Tokens consumed, by provider and kind (prompt/completion).",
+        }, []string{"provider", "kind"}),
+    }
+    m.Registry.MustRegister(m.RequestsTotal, m.ProviderLatency, m.ErrorsTotal, m.TokensTotal)
+    return m
+}
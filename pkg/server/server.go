@@ -0,0 +1,62 @@
+// Package server exposes the multi-provider fan-out + summarizer
+// pipeline as an OpenAI-compatible HTTP API, so existing OpenAI SDKs
+// can use llmhub as a drop-in aggregator.
+package server
+
+import (
+    "net/http"
+
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+    "go.uber.org/zap"
+
+    "github.com/turboOrange/llmhub/pkg/router"
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+// ConsensusModel is the special "model" value that triggers the
+// query-all-then-summarize flow instead of routing to one provider.
+const ConsensusModel = "consensus"
+
+// Server serves the OpenAI-compatible API backed by provs.
+type Server struct {
+    providers map[string]router.Provider
+    names     []string
+
+    rt       *router.Router
+    registry *tools.Registry
+    toolList []tools.Tool
+
+    logger  *zap.Logger
+    metrics *Metrics
+}
+
+// New builds a Server. The first provider in provs is the one used to
+// produce the consensus verdict.
+func New(provs []router.Provider, rt *router.Router, registry *tools.Registry, toolList []tools.Tool, logger *zap.Logger) *Server {
+    byName := make(map[string]router.Provider, len(provs))
+    names := make([]string, 0, len(provs))
+    for _, p := range provs {
+        byName[p.Name()] = p
+        names = append(names, p.Name())
+    }
+    return &Server{
+        providers: byName,
+        names:     names,
+        rt:        rt,
+        registry:  registry,
+        toolList:  toolList,
+        logger:    logger,
+        metrics:   NewMetrics(),
+    }
+}
+
+// Handler returns the http.Handler serving every route, including
+// /metrics.
+func (s *Server) Handler() http.Handler {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/v1/chat/completions", s.handleChatCompletions)
+    mux.HandleFunc("/v1/completions", s.handleCompletions)
+    mux.HandleFunc("/v1/models", s.handleModels)
+    mux.Handle("/metrics", promhttp.HandlerFor(s.metrics.Registry, promhttp.HandlerOpts{}))
+    return mux
+}
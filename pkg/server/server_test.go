@@ -0,0 +1,187 @@
+package server
+
+import (
+    "bufio"
+    "context"
+    "encoding/json"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "go.uber.org/zap"
+
+    "github.com/turboOrange/llmhub/pkg/providers"
+    "github.com/turboOrange/llmhub/pkg/router"
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+// fakeProvider answers with its own name, so tests can tell which
+// provider a response came from.
+type fakeProvider struct {
+    name   string
+    answer string
+}
+
+func (f *fakeProvider) Name() string  { return f.name }
+func (f *fakeProvider) Enabled() bool { return true }
+
+func (f *fakeProvider) Query(ctx context.Context, prompt string, extra map[string]string, toolList []tools.Tool) (tools.Response, error) {
+    answer := f.answer
+    if answer == "" {
+        answer = f.name + ": " + prompt
+    }
+    return tools.Response{Content: answer, FinishReason: "stop"}, nil
+}
+
+func (f *fakeProvider) QueryStream(ctx context.Context, prompt string, extra map[string]string) (<-chan providers.Chunk, error) {
+    out := make(chan providers.Chunk, 2)
+    out <- providers.Chunk{Text: f.name + ": " + prompt}
+    out <- providers.Chunk{FinishReason: "stop"}
+    close(out)
+    return out, nil
+}
+
+func newTestServer(provs ...*fakeProvider) *Server {
+    rp := make([]router.Provider, len(provs))
+    for i, p := range provs {
+        rp[i] = p
+    }
+    rt := router.New(router.Config{Policy: router.PolicyPriority}, zap.NewNop())
+    return New(rp, rt, tools.NewRegistry(), nil, zap.NewNop())
+}
+
+func TestHandleModels(t *testing.T) {
+    s := newTestServer(&fakeProvider{name: "openai"}, &fakeProvider{name: "anthropic"})
+
+    req := httptest.NewRequest("GET", "/v1/models", nil)
+    rec := httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, req)
+
+    if rec.Code != 200 {
+        t.Fatalf("status = %d, want 200", rec.Code)
+    }
+    var resp modelsResponse
+    if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+        t.Fatalf("decode response: %v", err)
+    }
+
+    ids := make(map[string]bool, len(resp.Data))
+    for _, m := range resp.Data {
+        ids[m.ID] = true
+    }
+    for _, want := range []string{ConsensusModel, "openai", "anthropic"} {
+        if !ids[want] {
+            t.Fatalf("models response %+v missing %q", resp.Data, want)
+        }
+    }
+}
+
+func TestHandleChatCompletionsSingleProvider(t *testing.T) {
+    s := newTestServer(&fakeProvider{name: "openai", answer: "hi there"})
+
+    body := strings.NewReader(`{"model":"openai","messages":[{"role":"user","content":"hello"}]}`)
+    req := httptest.NewRequest("POST", "/v1/chat/completions", body)
+    rec := httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, req)
+
+    if rec.Code != 200 {
+        t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+    }
+    var resp chatCompletionResponse
+    if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+        t.Fatalf("decode response: %v", err)
+    }
+    if len(resp.Choices) != 1 || resp.Choices[0].Message.Content != "hi there" {
+        t.Fatalf("choices = %+v, want a single choice with content %q", resp.Choices, "hi there")
+    }
+}
+
+func TestHandleChatCompletionsConsensus(t *testing.T) {
+    s := newTestServer(&fakeProvider{name: "openai", answer: "verdict"}, &fakeProvider{name: "anthropic", answer: "answer-a"})
+
+    body := strings.NewReader(`{"model":"consensus","messages":[{"role":"user","content":"hello"}]}`)
+    req := httptest.NewRequest("POST", "/v1/chat/completions", body)
+    rec := httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, req)
+
+    if rec.Code != 200 {
+        t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+    }
+    var resp chatCompletionResponse
+    if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+        t.Fatalf("decode response: %v", err)
+    }
+    // consensusAnswer summarizes via the first provider in s.names, which
+    // here is whichever provider New() iterated first; either fake's
+    // canned "answer" text confirms the agent loop ran end to end.
+    if len(resp.Choices) != 1 || resp.Choices[0].Message.Content == "" {
+        t.Fatalf("choices = %+v, want a non-empty consensus verdict", resp.Choices)
+    }
+}
+
+func TestHandleChatCompletionsStream(t *testing.T) {
+    s := newTestServer(&fakeProvider{name: "openai"})
+
+    body := strings.NewReader(`{"model":"openai","stream":true,"messages":[{"role":"user","content":"hello"}]}`)
+    req := httptest.NewRequest("POST", "/v1/chat/completions", body)
+    rec := httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, req)
+
+    if rec.Code != 200 {
+        t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+    }
+    if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+        t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+    }
+
+    var chunks []string
+    sawDone := false
+    scanner := bufio.NewScanner(strings.NewReader(rec.Body.String()))
+    for scanner.Scan() {
+        line := scanner.Text()
+        if !strings.HasPrefix(line, "data: ") {
+            continue
+        }
+        payload := strings.TrimPrefix(line, "data: ")
+        if payload == "[DONE]" {
+            sawDone = true
+            continue
+        }
+        chunks = append(chunks, payload)
+    }
+    if !sawDone {
+        t.Fatalf("stream body missing terminal [DONE] event: %s", rec.Body.String())
+    }
+    if len(chunks) == 0 {
+        t.Fatalf("stream body had no data chunks: %s", rec.Body.String())
+    }
+
+    var first chatCompletionChunk
+    if err := json.Unmarshal([]byte(chunks[0]), &first); err != nil {
+        t.Fatalf("decode first chunk: %v", err)
+    }
+    if first.Choices[0].Delta.Content != "openai: hello" {
+        t.Fatalf("first chunk delta = %q, want %q", first.Choices[0].Delta.Content, "openai: hello")
+    }
+}
+
+func TestMetricsSurviveMultipleServers(t *testing.T) {
+    // Regression test: NewMetrics used to register against prometheus's
+    // global DefaultRegisterer, so a second Server in the same process
+    // panicked with a duplicate-collector-registration error.
+    _ = newTestServer(&fakeProvider{name: "a"})
+    _ = newTestServer(&fakeProvider{name: "b"})
+}
+
+func TestHandleChatCompletionsUnknownModel(t *testing.T) {
+    s := newTestServer(&fakeProvider{name: "openai"})
+
+    body := strings.NewReader(`{"model":"does-not-exist","messages":[{"role":"user","content":"hello"}]}`)
+    req := httptest.NewRequest("POST", "/v1/chat/completions", body)
+    rec := httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, req)
+
+    if rec.Code != 502 {
+        t.Fatalf("status = %d, want %d for an unknown model", rec.Code, 502)
+    }
+}
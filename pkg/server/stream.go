@@ -0,0 +1,98 @@
+package server
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+)
+
+// streamAnswer writes Server-Sent-Events chat-completion chunks for
+// model/prompt, same shape OpenAI's streaming API uses. For the
+// consensus model the answer is computed in full first (there's no
+// single upstream stream to multiplex) and sent as one chunk; for a
+// named provider, its QueryStream output is forwarded chunk by chunk.
+func (s *Server) streamAnswer(ctx context.Context, w http.ResponseWriter, model, prompt string) {
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        http.Error(w, "streaming unsupported by this connection", http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    w.Header().Set("Connection", "keep-alive")
+
+    id := fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano())
+    created := time.Now().Unix()
+
+    send := func(delta, finishReason string) {
+        chunk := chatCompletionChunk{
+            ID:      id,
+            Object:  "chat.completion.chunk",
+            Created: created,
+            Model:   model,
+            Choices: []chatCompletionChunkChoice{{
+                Index:        0,
+                Delta:        chatMessageDelta{Content: delta},
+                FinishReason: finishReason,
+            }},
+        }
+        b, _ := json.Marshal(chunk)
+        fmt.Fprintf(w, "data: %s\n\n", b)
+        flusher.Flush()
+    }
+    done := func() {
+        fmt.Fprint(w, "data: [DONE]\n\n")
+        flusher.Flush()
+    }
+
+    if model == "" || model == ConsensusModel {
+        content, _, err := s.consensusAnswer(ctx, prompt)
+        if err != nil {
+            s.logger.Error("Consensus stream failed", zap.Error(err))
+            send("", "stop")
+            done()
+            return
+        }
+        send(content, "")
+        send("", "stop")
+        done()
+        return
+    }
+
+    p, ok := s.providers[model]
+    if !ok {
+        http.Error(w, fmt.Sprintf("unknown provider/model %q", model), http.StatusBadRequest)
+        return
+    }
+
+    stream, err := p.QueryStream(ctx, prompt, nil)
+    if err != nil {
+        s.logger.Error("Provider stream failed", zap.String("provider", model), zap.Error(err))
+        http.Error(w, err.Error(), http.StatusBadGateway)
+        return
+    }
+
+    for chunk := range stream {
+        if chunk.Err != nil {
+            s.logger.Error("Provider stream failed mid-stream", zap.String("provider", model), zap.Error(chunk.Err))
+            s.metrics.ErrorsTotal.WithLabelValues(model).Inc()
+            fmt.Fprintf(w, "data: {\"error\":%q}\n\n", chunk.Err.Error())
+            flusher.Flush()
+            done()
+            return
+        }
+        if chunk.Text != "" {
+            send(chunk.Text, "")
+        }
+        if chunk.FinishReason != "" {
+            s.metrics.TokensTotal.WithLabelValues(model, "prompt").Add(float64(chunk.Usage.Prompt))
+            s.metrics.TokensTotal.WithLabelValues(model, "completion").Add(float64(chunk.Usage.Completion))
+            send("", chunk.FinishReason)
+        }
+    }
+    done()
+}
@@ -0,0 +1,88 @@
+package server
+
+// chatMessage mirrors OpenAI's chat message shape. Only Role and
+// Content are modeled; llmhub doesn't track tool/function messages on
+// the wire yet.
+type chatMessage struct {
+    Role    string `json:"role"`
+    Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+    Model    string        `json:"model"`
+    Messages []chatMessage `json:"messages"`
+    Stream   bool          `json:"stream"`
+}
+
+type chatCompletionChoice struct {
+    Index        int         `json:"index"`
+    Message      chatMessage `json:"message"`
+    FinishReason string      `json:"finish_reason"`
+}
+
+type usagePayload struct {
+    PromptTokens     int `json:"prompt_tokens"`
+    CompletionTokens int `json:"completion_tokens"`
+    TotalTokens      int `json:"total_tokens"`
+}
+
+type chatCompletionResponse struct {
+    ID      string                  `json:"id"`
+    Object  string                  `json:"object"`
+    Created int64                   `json:"created"`
+    Model   string                  `json:"model"`
+    Choices []chatCompletionChoice  `json:"choices"`
+    Usage   *usagePayload           `json:"usage,omitempty"`
+}
+
+type chatMessageDelta struct {
+    Content string `json:"content,omitempty"`
+}
+
+type chatCompletionChunkChoice struct {
+    Index        int              `json:"index"`
+    Delta        chatMessageDelta `json:"delta"`
+    FinishReason string           `json:"finish_reason,omitempty"`
+}
+
+type chatCompletionChunk struct {
+    ID      string                       `json:"id"`
+    Object  string                       `json:"object"`
+    Created int64                        `json:"created"`
+    Model   string                       `json:"model"`
+    Choices []chatCompletionChunkChoice  `json:"choices"`
+}
+
+// completionRequest/Response model the legacy /v1/completions shape,
+// where the prompt is a plain string rather than a message list.
+type completionRequest struct {
+    Model  string `json:"model"`
+    Prompt string `json:"prompt"`
+    Stream bool   `json:"stream"`
+}
+
+type completionChoice struct {
+    Index        int    `json:"index"`
+    Text         string `json:"text"`
+    FinishReason string `json:"finish_reason"`
+}
+
+type completionResponse struct {
+    ID      string              `json:"id"`
+    Object  string              `json:"object"`
+    Created int64               `json:"created"`
+    Model   string              `json:"model"`
+    Choices []completionChoice  `json:"choices"`
+    Usage   *usagePayload       `json:"usage,omitempty"`
+}
+
+type modelPayload struct {
+    ID      string `json:"id"`
+    Object  string `json:"object"`
+    OwnedBy string `json:"owned_by"`
+}
+
+type modelsResponse struct {
+    Object string         `json:"object"`
+    Data   []modelPayload `json:"data"`
+}
@@ -0,0 +1,19 @@
+package server
+
+import "github.com/turboOrange/llmhub/pkg/providers"
+
+// totalUsage sums token usage across every provider queried, or nil if
+// none is known (e.g. a request answered by a single named provider,
+// whose Query call reports no usage).
+func totalUsage(usage map[string]providers.TokenUsage) *usagePayload {
+    if len(usage) == 0 {
+        return nil
+    }
+    var total usagePayload
+    for _, u := range usage {
+        total.PromptTokens += u.Prompt
+        total.CompletionTokens += u.Completion
+        total.TotalTokens += u.Total
+    }
+    return &total
+}
@@ -0,0 +1,47 @@
+package tools
+
+import (
+    "context"
+    "fmt"
+    "sync"
+)
+
+// Func implements a tool's behavior: it receives the raw JSON arguments
+// the model produced and returns the result to feed back as a
+// ToolMessage.
+type Func func(ctx context.Context, arguments string) (string, error)
+
+// Registry maps tool names to the Go funcs that implement them.
+type Registry struct {
+    mu    sync.RWMutex
+    funcs map[string]Func
+}
+
+// NewRegistry returns an empty Registry ready for Register calls.
+func NewRegistry() *Registry {
+    return &Registry{funcs: make(map[string]Func)}
+}
+
+// Register adds or replaces the implementation for a tool name.
+func (r *Registry) Register(name string, fn Func) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.funcs[name] = fn
+}
+
+// Execute runs the registered Func for call.Name and wraps its result
+// as a ToolMessage ready to feed back to the model.
+func (r *Registry) Execute(ctx context.Context, call ToolCall) (ToolMessage, error) {
+    r.mu.RLock()
+    fn, ok := r.funcs[call.Name]
+    r.mu.RUnlock()
+    if !ok {
+        return ToolMessage{}, fmt.Errorf("no tool registered for %q", call.Name)
+    }
+
+    result, err := fn(ctx, call.Arguments)
+    if err != nil {
+        return ToolMessage{}, fmt.Errorf("tool %q: %w", call.Name, err)
+    }
+    return ToolMessage{ToolCallID: call.ID, Content: result}, nil
+}
@@ -0,0 +1,28 @@
+package tools
+
+import (
+    "context"
+    "testing"
+)
+
+func TestRegistryExecute(t *testing.T) {
+    r := NewRegistry()
+    r.Register("echo", func(ctx context.Context, arguments string) (string, error) {
+        return "echo: " + arguments, nil
+    })
+
+    msg, err := r.Execute(context.Background(), ToolCall{ID: "1", Name: "echo", Arguments: "hi"})
+    if err != nil {
+        t.Fatalf("Execute returned error: %v", err)
+    }
+    if msg.ToolCallID != "1" || msg.Content != "echo: hi" {
+        t.Fatalf("Execute() = %+v, want ToolCallID=1 Content=\"echo: hi\"", msg)
+    }
+}
+
+func TestRegistryExecuteUnknownTool(t *testing.T) {
+    r := NewRegistry()
+    if _, err := r.Execute(context.Background(), ToolCall{ID: "1", Name: "missing"}); err == nil {
+        t.Fatal("expected an error for an unregistered tool")
+    }
+}
@@ -0,0 +1,36 @@
+// Package tools defines a provider-agnostic function-calling layer so
+// the same Tool definitions and ToolCall/ToolMessage plumbing work
+// whether the backend is OpenAI, Anthropic, Gemini, or a gRPC plugin.
+package tools
+
+// Tool describes a function a model may call, as a JSON Schema object
+// under Parameters (e.g. {"type": "object", "properties": {...}}).
+type Tool struct {
+    Name        string                 `json:"name"`
+    Description string                 `json:"description"`
+    Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// ToolCall is a single invocation a model asked for. Arguments is the
+// raw JSON the model produced for Parameters.
+type ToolCall struct {
+    ID        string
+    Name      string
+    Arguments string
+}
+
+// ToolMessage is the result of executing a ToolCall, fed back to the
+// model on the next turn.
+type ToolMessage struct {
+    ToolCallID string
+    Content    string
+}
+
+// Response is what Provider.Query returns instead of a bare string once
+// tools are in play: either a final Content answer, or one or more
+// ToolCalls the caller must execute and feed back via ToolMessage.
+type Response struct {
+    Content      string
+    ToolCalls    []ToolCall
+    FinishReason string
+}
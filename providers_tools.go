@@ -0,0 +1,62 @@
+package main
+
+import (
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+// openAIToolsPayload translates toolList into the shape OpenAI's chat
+// completions API expects for its "tools" request field:
+// [{"type": "function", "function": {"name", "description", "parameters"}}].
+func openAIToolsPayload(toolList []tools.Tool) []map[string]interface{} {
+    if len(toolList) == 0 {
+        return nil
+    }
+    out := make([]map[string]interface{}, len(toolList))
+    for i, t := range toolList {
+        out[i] = map[string]interface{}{
+            "type": "function",
+            "function": map[string]interface{}{
+                "name":        t.Name,
+                "description": t.Description,
+                "parameters":  t.Parameters,
+            },
+        }
+    }
+    return out
+}
+
+// anthropicToolsPayload translates toolList into the shape the Messages
+// API expects for its "tools" request field:
+// [{"name", "description", "input_schema"}].
+func anthropicToolsPayload(toolList []tools.Tool) []map[string]interface{} {
+    if len(toolList) == 0 {
+        return nil
+    }
+    out := make([]map[string]interface{}, len(toolList))
+    for i, t := range toolList {
+        out[i] = map[string]interface{}{
+            "name":         t.Name,
+            "description":  t.Description,
+            "input_schema": t.Parameters,
+        }
+    }
+    return out
+}
+
+// geminiToolsPayload translates toolList into the shape Gemini's
+// generateContent API expects for its "tools" request field:
+// [{"functionDeclarations": [{"name", "description", "parameters"}]}].
+func geminiToolsPayload(toolList []tools.Tool) []map[string]interface{} {
+    if len(toolList) == 0 {
+        return nil
+    }
+    decls := make([]map[string]interface{}, len(toolList))
+    for i, t := range toolList {
+        decls[i] = map[string]interface{}{
+            "name":        t.Name,
+            "description": t.Description,
+            "parameters":  t.Parameters,
+        }
+    }
+    return []map[string]interface{}{{"functionDeclarations": decls}}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/turboOrange/llmhub/pkg/tools"
+)
+
+func sampleTool() tools.Tool {
+    return tools.Tool{
+        Name:        "current_time",
+        Description: "Returns the current time",
+        Parameters:  map[string]interface{}{"type": "object"},
+    }
+}
+
+func TestOpenAIToolsPayload(t *testing.T) {
+    got := openAIToolsPayload([]tools.Tool{sampleTool()})
+    want := []map[string]interface{}{{
+        "type": "function",
+        "function": map[string]interface{}{
+            "name":        "current_time",
+            "description": "Returns the current time",
+            "parameters":  map[string]interface{}{"type": "object"},
+        },
+    }}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("openAIToolsPayload() = %#v, want %#v", got, want)
+    }
+}
+
+func TestAnthropicToolsPayload(t *testing.T) {
+    got := anthropicToolsPayload([]tools.Tool{sampleTool()})
+    want := []map[string]interface{}{{
+        "name":         "current_time",
+        "description":  "Returns the current time",
+        "input_schema": map[string]interface{}{"type": "object"},
+    }}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("anthropicToolsPayload() = %#v, want %#v", got, want)
+    }
+}
+
+func TestGeminiToolsPayload(t *testing.T) {
+    got := geminiToolsPayload([]tools.Tool{sampleTool()})
+    want := []map[string]interface{}{{
+        "functionDeclarations": []map[string]interface{}{{
+            "name":        "current_time",
+            "description": "Returns the current time",
+            "parameters":  map[string]interface{}{"type": "object"},
+        }},
+    }}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("geminiToolsPayload() = %#v, want %#v", got, want)
+    }
+}
+
+func TestToolsPayloadEmpty(t *testing.T) {
+    if got := openAIToolsPayload(nil); got != nil {
+        t.Fatalf("openAIToolsPayload(nil) = %#v, want nil", got)
+    }
+}